@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type AccountConfig struct {
+	DeletionGracePeriod time.Duration
+	PurgeInterval       time.Duration
+}
+
+// GetAccountConfig reads how long a deleted account can be restored
+// (ACCOUNT_DELETION_GRACE_DAYS) and how often accounts past it are purged
+// (ACCOUNT_PURGE_INTERVAL_MINUTES).
+func GetAccountConfig() AccountConfig {
+	minutes, err := strconv.Atoi(os.Getenv("ACCOUNT_PURGE_INTERVAL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.ENUM_ACCOUNT_PURGE_INTERVAL_MINUTES
+	}
+
+	return AccountConfig{
+		DeletionGracePeriod: getHours("ACCOUNT_DELETION_GRACE_DAYS", constants.ENUM_ACCOUNT_DELETION_GRACE_DAYS) * 24,
+		PurgeInterval:       time.Duration(minutes) * time.Minute,
+	}
+}
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+var (
+	ErrNoSigningKey       = errors.New("no jwt signing key configured, set JWT_KEYS")
+	ErrNoActiveSigningKey = errors.New("no jwt signing key is active yet, at least one JWT_KEYS entry must activate now or earlier")
+	ErrInvalidSigningKey  = errors.New("jwt signing key must be an RSA or Ed25519 private key")
+	ErrDuplicateSigningID = errors.New("duplicate jwt key id")
+)
+
+type JWTKey struct {
+	ID          string
+	Algorithm   string
+	PrivateKey  crypto.Signer
+	ActivatesAt time.Time
+}
+
+type JWTConfig struct {
+	Issuer string
+	Keys   []JWTKey
+}
+
+// GetJWTConfig reads the issuer set on access tokens (JWT_ISSUER) and the
+// keys they are signed with (JWT_KEYS). JWT_KEYS is a comma separated list of
+// kid=path entries pointing at PEM encoded RSA or Ed25519 private keys, an
+// entry may end with @<RFC3339 time> to only start signing from then on.
+// Every listed key is published in the JWKS, so a rotation is scheduled by
+// adding the next key with a future activation time and dropping the old one
+// once its tokens have expired. Outside production an ephemeral key is
+// generated when none is configured, while a list of keys that all activate
+// later is rejected since nothing could be signed until then.
+func GetJWTConfig() (JWTConfig, error) {
+	issuer := os.Getenv("JWT_ISSUER")
+	if issuer == "" {
+		issuer = "twitter-clone-api"
+	}
+
+	keys, err := ParseJWTKeys(os.Getenv("JWT_KEYS"))
+	if err != nil {
+		return JWTConfig{}, err
+	}
+
+	if len(keys) == 0 {
+		if os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION {
+			return JWTConfig{}, ErrNoSigningKey
+		}
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return JWTConfig{}, err
+		}
+
+		log.Println("warning: JWT_KEYS is not set, signing tokens with an ephemeral key")
+		keys = append(keys, JWTKey{
+			ID:         "ephemeral",
+			Algorithm:  constants.ENUM_JWT_ALG_EDDSA,
+			PrivateKey: privateKey,
+		})
+	}
+
+	now := time.Now()
+	active := false
+	for _, key := range keys {
+		if !key.ActivatesAt.After(now) {
+			active = true
+			break
+		}
+	}
+
+	if !active {
+		return JWTConfig{}, ErrNoActiveSigningKey
+	}
+
+	return JWTConfig{
+		Issuer: issuer,
+		Keys:   keys,
+	}, nil
+}
+
+func ParseJWTKeys(value string) ([]JWTKey, error) {
+	var keys []JWTKey
+	seen := map[string]bool{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q", entry)
+		}
+
+		if seen[kid] {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateSigningID, kid)
+		}
+		seen[kid] = true
+
+		var activatesAt time.Time
+		path, at, scheduled := strings.Cut(path, "@")
+		if scheduled {
+			parsed, err := time.Parse(time.RFC3339, at)
+			if err != nil {
+				return nil, fmt.Errorf("invalid activation time for jwt key %q: %w", kid, err)
+			}
+			activatesAt = parsed
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read jwt key %q: %w", kid, err)
+		}
+
+		key, err := ParseJWTPrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse jwt key %q: %w", kid, err)
+		}
+
+		key.ID = kid
+		key.ActivatesAt = activatesAt
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// ParseJWTPrivateKey accepts PKCS#8 keys as written by
+// `openssl genpkey -algorithm ed25519` or `-algorithm RSA`, and PKCS#1 RSA keys.
+func ParseJWTPrivateKey(data []byte) (JWTKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return JWTKey{}, ErrInvalidSigningKey
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return JWTKey{}, ErrInvalidSigningKey
+	}
+	if err != nil {
+		return JWTKey{}, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < 2048 {
+			return JWTKey{}, errors.New("rsa jwt keys must be at least 2048 bits")
+		}
+		return JWTKey{Algorithm: constants.ENUM_JWT_ALG_RS256, PrivateKey: key}, nil
+	case ed25519.PrivateKey:
+		return JWTKey{Algorithm: constants.ENUM_JWT_ALG_EDDSA, PrivateKey: key}, nil
+	default:
+		return JWTKey{}, ErrInvalidSigningKey
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type MailConfig struct {
+	Driver  string
+	FileDir string
+}
+
+// GetMailConfig returns how emails are delivered. "smtp" (the default) sends
+// through the SMTP_* settings, "file" writes every email into MAIL_FILE_DIR
+// and "memory" keeps them in process, which is meant for tests.
+func GetMailConfig() MailConfig {
+	driver := os.Getenv("MAIL_DRIVER")
+	switch driver {
+	case constants.ENUM_MAIL_DRIVER_SMTP, constants.ENUM_MAIL_DRIVER_FILE, constants.ENUM_MAIL_DRIVER_MEMORY:
+	case "":
+		driver = constants.ENUM_MAIL_DRIVER_SMTP
+	default:
+		log.Printf("unknown MAIL_DRIVER %q, using %q", driver, constants.ENUM_MAIL_DRIVER_SMTP)
+		driver = constants.ENUM_MAIL_DRIVER_SMTP
+	}
+
+	fileDir := os.Getenv("MAIL_FILE_DIR")
+	if fileDir == "" {
+		fileDir = "./mail"
+	}
+
+	return MailConfig{
+		Driver:  driver,
+		FileDir: fileDir,
+	}
+}
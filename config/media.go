@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type MediaConfig struct {
+	MaxImageSize int64
+	MaxGIFSize   int64
+	MaxVideoSize int64
+}
+
+// GetMediaConfig reads the largest image (MEDIA_MAX_IMAGE_MB), GIF
+// (MEDIA_MAX_GIF_MB) and video (MEDIA_MAX_VIDEO_MB) a post may carry. The
+// image limit also applies to profile images and banners.
+func GetMediaConfig() MediaConfig {
+	return MediaConfig{
+		MaxImageSize: getMegabytes("MEDIA_MAX_IMAGE_MB", constants.ENUM_MEDIA_MAX_IMAGE_MB),
+		MaxGIFSize:   getMegabytes("MEDIA_MAX_GIF_MB", constants.ENUM_MEDIA_MAX_GIF_MB),
+		MaxVideoSize: getMegabytes("MEDIA_MAX_VIDEO_MB", constants.ENUM_MEDIA_MAX_VIDEO_MB),
+	}
+}
+
+// MaxRequestSize is the largest post body that can pass the limits, either
+// four images or one GIF or video, plus room for the text fields.
+func (c MediaConfig) MaxRequestSize() int64 {
+	return max(constants.ENUM_MEDIA_MAX_IMAGES*c.MaxImageSize, c.MaxGIFSize, c.MaxVideoSize) + 1<<20
+}
+
+// MaxProfileRequestSize is the largest profile update, a picture and a
+// banner plus room for the text fields.
+func (c MediaConfig) MaxProfileRequestSize() int64 {
+	return 2*c.MaxImageSize + 1<<20
+}
+
+func getMegabytes(key string, fallback int) int64 {
+	megabytes, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || megabytes <= 0 {
+		megabytes = fallback
+	}
+
+	return int64(megabytes) << 20
+}
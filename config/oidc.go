@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig
+	StateTTL  time.Duration
+}
+
+// GetOIDCConfig reads the enabled providers from OIDC_PROVIDERS, a comma
+// separated list of names. Each one is configured through
+// OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID, OIDC_<NAME>_CLIENT_SECRET and
+// optionally OIDC_<NAME>_SCOPES and OIDC_<NAME>_REDIRECT_URL, the page the
+// provider sends users back to (OIDC_REDIRECT_URL, APP_URL/oidc/callback
+// when unset). OIDC_STATE_TTL_MINUTES limits how long a login may take.
+func GetOIDCConfig() OIDCConfig {
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if redirectURL == "" {
+		redirectURL = strings.TrimRight(getAppURL(), "/") + "/oidc/callback"
+	}
+
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:         name,
+			Issuer:       strings.TrimRight(os.Getenv(prefix+"ISSUER"), "/"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes:       strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+
+		if provider.RedirectURL == "" {
+			provider.RedirectURL = redirectURL
+		}
+
+		if len(provider.Scopes) == 0 {
+			provider.Scopes = []string{"openid", "email", "profile"}
+		}
+
+		providers = append(providers, provider)
+	}
+
+	minutes, err := strconv.Atoi(os.Getenv("OIDC_STATE_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.ENUM_OIDC_STATE_TTL_MINUTES
+	}
+
+	return OIDCConfig{
+		Providers: providers,
+		StateTTL:  time.Duration(minutes) * time.Minute,
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type PasswordResetConfig struct {
+	URL string
+	TTL time.Duration
+}
+
+// GetPasswordResetConfig reads the page reset links open (PASSWORD_RESET_URL,
+// APP_URL/reset-password when unset), the token is appended as ?token=, and
+// how long a link stays valid (PASSWORD_RESET_TTL_MINUTES).
+func GetPasswordResetConfig() PasswordResetConfig {
+	url := os.Getenv("PASSWORD_RESET_URL")
+	if url == "" {
+		url = strings.TrimRight(getAppURL(), "/") + "/reset-password"
+	}
+
+	minutes, err := strconv.Atoi(os.Getenv("PASSWORD_RESET_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.ENUM_PASSWORD_RESET_TTL_MINUTES
+	}
+
+	return PasswordResetConfig{
+		URL: url,
+		TTL: time.Duration(minutes) * time.Minute,
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type PostEditConfig struct {
+	Window   time.Duration
+	MaxEdits int
+}
+
+// GetPostEditConfig reads for how long after posting a post can be edited
+// (POST_EDIT_WINDOW_MINUTES) and how often (POST_MAX_EDITS).
+func GetPostEditConfig() PostEditConfig {
+	maxEdits, err := strconv.Atoi(os.Getenv("POST_MAX_EDITS"))
+	if err != nil || maxEdits < 0 {
+		maxEdits = constants.ENUM_POST_MAX_EDITS
+	}
+
+	return PostEditConfig{
+		Window:   getMinutes("POST_EDIT_WINDOW_MINUTES", constants.ENUM_POST_EDIT_WINDOW_MINUTES),
+		MaxEdits: maxEdits,
+	}
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+// Rate lets Limit requests through per Period, a bucket refills one request
+// every Period/Limit. A zero Limit turns the limiter off.
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+type RateLimitConfig struct {
+	Auth        Rate
+	Post        Rate
+	Interaction Rate
+	Lookup      Rate
+}
+
+type LoginLockoutConfig struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// GetRateLimitConfig reads the per ip limit of login and other unauthenticated
+// account endpoints (RATE_LIMIT_AUTH), the per user limit of new posts, reposts
+// and messages (RATE_LIMIT_POST), of likes, follows and bookmarks
+// (RATE_LIMIT_INTERACTION) and the per ip limit of username checks and
+// profile lookups (RATE_LIMIT_LOOKUP). Each is written as <limit>/<period>,
+// e.g. 10/1m.
+func GetRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		Auth:        getRate("RATE_LIMIT_AUTH", constants.ENUM_RATE_LIMIT_AUTH),
+		Post:        getRate("RATE_LIMIT_POST", constants.ENUM_RATE_LIMIT_POST),
+		Interaction: getRate("RATE_LIMIT_INTERACTION", constants.ENUM_RATE_LIMIT_INTERACTION),
+		Lookup:      getRate("RATE_LIMIT_LOOKUP", constants.ENUM_RATE_LIMIT_LOOKUP),
+	}
+}
+
+// GetLoginLockoutConfig reads after how many failed logins in a row an account
+// is locked (LOGIN_LOCKOUT_THRESHOLD), for how long (LOGIN_LOCKOUT_MINUTES,
+// doubled on every further failure) and the longest a lock lasts
+// (LOGIN_LOCKOUT_MAX_MINUTES).
+func GetLoginLockoutConfig() LoginLockoutConfig {
+	threshold, err := strconv.Atoi(os.Getenv("LOGIN_LOCKOUT_THRESHOLD"))
+	if err != nil || threshold <= 0 {
+		threshold = constants.ENUM_LOGIN_LOCKOUT_THRESHOLD
+	}
+
+	return LoginLockoutConfig{
+		Threshold:   threshold,
+		Duration:    getMinutes("LOGIN_LOCKOUT_MINUTES", constants.ENUM_LOGIN_LOCKOUT_MINUTES),
+		MaxDuration: getMinutes("LOGIN_LOCKOUT_MAX_MINUTES", constants.ENUM_LOGIN_LOCKOUT_MAX_MINUTES),
+	}
+}
+
+func getRate(key string, fallback string) Rate {
+	value := os.Getenv(key)
+	if value == "" {
+		value = fallback
+	}
+
+	rate, ok := ParseRate(value)
+	if !ok {
+		log.Printf("invalid %s %q, using %s", key, value, fallback)
+		rate, _ = ParseRate(fallback)
+	}
+
+	return rate
+}
+
+func ParseRate(value string) (Rate, bool) {
+	limit, period, found := strings.Cut(value, "/")
+	if !found {
+		return Rate{}, false
+	}
+
+	l, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || l < 0 {
+		return Rate{}, false
+	}
+
+	p, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || p <= 0 {
+		return Rate{}, false
+	}
+
+	return Rate{
+		Limit:  l,
+		Period: p,
+	}, true
+}
+
+func getMinutes(key string, fallback int) time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || minutes <= 0 {
+		minutes = fallback
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
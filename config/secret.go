@@ -0,0 +1,46 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+var (
+	ephemeralSecretsMu sync.Mutex
+	ephemeralSecrets   = map[string]string{}
+)
+
+// getSecret reads a signing secret from key. Production refuses to start
+// without one, elsewhere an ephemeral secret is generated once per process,
+// so whatever was signed with it stops working on restart.
+func getSecret(key string) (string, error) {
+	if secret := os.Getenv(key); secret != "" {
+		return secret, nil
+	}
+
+	if os.Getenv("APP_ENV") == constants.ENUM_RUN_PRODUCTION {
+		return "", fmt.Errorf("no secret configured, set %s", key)
+	}
+
+	ephemeralSecretsMu.Lock()
+	defer ephemeralSecretsMu.Unlock()
+
+	if secret, ok := ephemeralSecrets[key]; ok {
+		return secret, nil
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	log.Printf("warning: %s is not set, signing with an ephemeral secret", key)
+	ephemeralSecrets[key] = base64.RawURLEncoding.EncodeToString(buf)
+	return ephemeralSecrets[key], nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type SessionConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// GetSessionConfig reads how long access tokens (ACCESS_TOKEN_TTL_MINUTES)
+// and idle sessions (REFRESH_TOKEN_TTL_DAYS) stay valid.
+func GetSessionConfig() SessionConfig {
+	minutes, err := strconv.Atoi(os.Getenv("ACCESS_TOKEN_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.ENUM_ACCESS_TOKEN_TTL_MINUTES
+	}
+
+	return SessionConfig{
+		AccessTokenTTL:  time.Duration(minutes) * time.Minute,
+		RefreshTokenTTL: getHours("REFRESH_TOKEN_TTL_DAYS", constants.ENUM_REFRESH_TOKEN_TTL_DAYS) * 24,
+	}
+}
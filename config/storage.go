@@ -0,0 +1,93 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool
+}
+
+type StorageConfig struct {
+	Driver   string
+	URLTTL   time.Duration
+	LocalDir string
+	LocalURL string
+	Secret   string
+	S3       S3Config
+}
+
+// GetStorageConfig reads where uploads are kept (STORAGE_DRIVER) and for how
+// long the signed links handed out for them work (STORAGE_URL_TTL_MINUTES).
+// "local" (the default) writes below STORAGE_LOCAL_DIR and serves files from
+// APP_URL/assets with links signed by STORAGE_URL_SECRET, which production
+// requires for it. "s3" stores them in S3_BUCKET at S3_ENDPOINT, which can be any
+// S3 compatible service such as MinIO, and hands out presigned links.
+func GetStorageConfig() (StorageConfig, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	switch driver {
+	case constants.ENUM_STORAGE_DRIVER_LOCAL, constants.ENUM_STORAGE_DRIVER_S3:
+	case "":
+		driver = constants.ENUM_STORAGE_DRIVER_LOCAL
+	default:
+		log.Printf("unknown STORAGE_DRIVER %q, using %q", driver, constants.ENUM_STORAGE_DRIVER_LOCAL)
+		driver = constants.ENUM_STORAGE_DRIVER_LOCAL
+	}
+
+	localDir := os.Getenv("STORAGE_LOCAL_DIR")
+	if localDir == "" {
+		localDir = "./assets"
+	}
+
+	// s3 links are presigned with the access key instead
+	var secret string
+	if driver == constants.ENUM_STORAGE_DRIVER_LOCAL {
+		var err error
+		secret, err = getSecret("STORAGE_URL_SECRET")
+		if err != nil {
+			return StorageConfig{}, err
+		}
+	}
+
+	region := os.Getenv("S3_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	// buckets are addressed by path on custom endpoints, MinIO doesn't
+	// resolve bucket subdomains by default
+	endpoint := strings.TrimRight(os.Getenv("S3_ENDPOINT"), "/")
+	pathStyle := endpoint != ""
+	if endpoint == "" {
+		endpoint = "https://s3." + region + ".amazonaws.com"
+	}
+	if value := os.Getenv("S3_PATH_STYLE"); value != "" {
+		pathStyle = value == "true"
+	}
+
+	return StorageConfig{
+		Driver:   driver,
+		URLTTL:   getMinutes("STORAGE_URL_TTL_MINUTES", constants.ENUM_STORAGE_URL_TTL_MINUTES),
+		LocalDir: localDir,
+		LocalURL: strings.TrimRight(getAppURL(), "/") + "/assets",
+		Secret:   secret,
+		S3: S3Config{
+			Endpoint:        endpoint,
+			Region:          region,
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       pathStyle,
+		},
+	}, nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+// GetTimelineStrategy returns how home timelines are built. "fanout" writes
+// every new post into its followers' timelines, "fanin" (the default) merges
+// followed accounts' posts at read time.
+func GetTimelineStrategy() string {
+	strategy := os.Getenv("TIMELINE_STRATEGY")
+
+	switch strategy {
+	case constants.ENUM_TIMELINE_FAN_IN, constants.ENUM_TIMELINE_FAN_OUT:
+		return strategy
+	case "":
+		return constants.ENUM_TIMELINE_FAN_IN
+	default:
+		log.Printf("unknown TIMELINE_STRATEGY %q, using %q", strategy, constants.ENUM_TIMELINE_FAN_IN)
+		return constants.ENUM_TIMELINE_FAN_IN
+	}
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type TrendsConfig struct {
+	Window   time.Duration
+	HalfLife time.Duration
+}
+
+// GetTrendsConfig reads how far back trends look (TRENDS_WINDOW_HOURS) and
+// how fast older usages lose weight (TRENDS_HALF_LIFE_HOURS).
+func GetTrendsConfig() TrendsConfig {
+	return TrendsConfig{
+		Window:   getHours("TRENDS_WINDOW_HOURS", constants.ENUM_TRENDS_WINDOW_HOURS),
+		HalfLife: getHours("TRENDS_HALF_LIFE_HOURS", constants.ENUM_TRENDS_HALF_LIFE_HOURS),
+	}
+}
+
+func getHours(key string, fallback int) time.Duration {
+	hours, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || hours <= 0 {
+		hours = fallback
+	}
+
+	return time.Duration(hours) * time.Hour
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type TwoFactorConfig struct {
+	Issuer        string
+	Secret        string
+	EncryptionKey string
+	ChallengeTTL  time.Duration
+}
+
+// GetTwoFactorConfig reads the issuer shown in authenticator apps (APP_NAME),
+// how long a login challenge can be answered (TWO_FACTOR_CHALLENGE_TTL_MINUTES),
+// the key challenges are signed with (TWO_FACTOR_SECRET) and the key stored
+// authenticator secrets are encrypted with (TWO_FACTOR_ENCRYPTION_KEY). Both
+// keys are required in production.
+func GetTwoFactorConfig() (TwoFactorConfig, error) {
+	secret, err := getSecret("TWO_FACTOR_SECRET")
+	if err != nil {
+		return TwoFactorConfig{}, err
+	}
+
+	encryptionKey, err := getSecret("TWO_FACTOR_ENCRYPTION_KEY")
+	if err != nil {
+		return TwoFactorConfig{}, err
+	}
+
+	issuer := os.Getenv("APP_NAME")
+	if issuer == "" {
+		issuer = "Twitter Clone"
+	}
+
+	minutes, err := strconv.Atoi(os.Getenv("TWO_FACTOR_CHALLENGE_TTL_MINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = constants.ENUM_TWO_FACTOR_CHALLENGE_TTL_MINUTES
+	}
+
+	return TwoFactorConfig{
+		Issuer:        issuer,
+		Secret:        secret,
+		EncryptionKey: encryptionKey,
+		ChallengeTTL:  time.Duration(minutes) * time.Minute,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type VerificationConfig struct {
+	Secret         string
+	BaseURL        string
+	TTL            time.Duration
+	ResendInterval time.Duration
+}
+
+// GetVerificationConfig reads the key verification links are signed with
+// (VERIFICATION_SECRET, required in production), the public url they point
+// to (APP_URL), how long they stay valid (EMAIL_VERIFICATION_TTL_HOURS) and
+// how often one can be resent (EMAIL_VERIFICATION_RESEND_SECONDS).
+func GetVerificationConfig() (VerificationConfig, error) {
+	secret, err := getSecret("VERIFICATION_SECRET")
+	if err != nil {
+		return VerificationConfig{}, err
+	}
+
+	seconds, err := strconv.Atoi(os.Getenv("EMAIL_VERIFICATION_RESEND_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = constants.ENUM_EMAIL_VERIFICATION_RESEND_SECONDS
+	}
+
+	return VerificationConfig{
+		Secret:         secret,
+		BaseURL:        getAppURL(),
+		TTL:            getHours("EMAIL_VERIFICATION_TTL_HOURS", constants.ENUM_EMAIL_VERIFICATION_TTL_HOURS),
+		ResendInterval: time.Duration(seconds) * time.Second,
+	}, nil
+}
+
+// getAppURL is the public url links sent out of the api point to (APP_URL)
+func getAppURL() string {
+	baseURL := os.Getenv("APP_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8888"
+	}
+
+	return baseURL
+}
@@ -10,6 +10,89 @@ const (
 	ENUM_PAGINATION_PER_PAGE = 10
 	ENUM_PAGINATION_PAGE = 1
 
+	ENUM_TIMELINE_FAN_IN = "fanin"
+	ENUM_TIMELINE_FAN_OUT = "fanout"
+
+	ENUM_TRENDS_LIMIT = 10
+	ENUM_TRENDS_WINDOW_HOURS = 24
+	ENUM_TRENDS_HALF_LIFE_HOURS = 6
+	ENUM_POST_EDIT_WINDOW_MINUTES = 60
+	ENUM_POST_MAX_EDITS = 5
+
+	ENUM_POLL_MIN_OPTIONS = 2
+	ENUM_POLL_MAX_OPTIONS = 4
+	ENUM_POLL_OPTION_MAX_LENGTH = 25
+	ENUM_POLL_MIN_DURATION_MINUTES = 5
+	ENUM_POLL_MAX_DURATION_MINUTES = 10080
+
+	ENUM_MEDIA_IMAGE = "image"
+	ENUM_MEDIA_GIF = "gif"
+	ENUM_MEDIA_VIDEO = "video"
+	ENUM_MEDIA_MAX_IMAGES = 4
+	ENUM_MEDIA_ALT_TEXT_MAX_LENGTH = 1000
+	ENUM_MEDIA_MAX_IMAGE_MB = 5
+	ENUM_MEDIA_MAX_GIF_MB = 15
+	ENUM_MEDIA_MAX_VIDEO_MB = 100
+	ENUM_MEDIA_MAX_PROFILE_IMAGE_PIXELS = 40000000
+
+	ENUM_NOTIFICATION_LIKE = "like"
+	ENUM_NOTIFICATION_REPLY = "reply"
+	ENUM_NOTIFICATION_FOLLOW = "follow"
+	ENUM_NOTIFICATION_MENTION = "mention"
+
+	ENUM_CONVERSATION_MAX_PARTICIPANTS = 10
+	ENUM_MESSAGE_PAGE_LIMIT = 30
+
+	ENUM_ACCESS_TOKEN_TTL_MINUTES = 15
+	ENUM_REFRESH_TOKEN_TTL_DAYS = 30
+
+	ENUM_JWT_ALG_RS256 = "RS256"
+	ENUM_JWT_ALG_EDDSA = "EdDSA"
+
+	ENUM_MAIL_DRIVER_SMTP = "smtp"
+	ENUM_MAIL_DRIVER_FILE = "file"
+	ENUM_MAIL_DRIVER_MEMORY = "memory"
+
+	ENUM_STORAGE_DRIVER_LOCAL = "local"
+	ENUM_STORAGE_DRIVER_S3 = "s3"
+	ENUM_STORAGE_URL_TTL_MINUTES = 60
+
+	ENUM_EMAIL_VERIFICATION_TTL_HOURS = 24
+	ENUM_EMAIL_VERIFICATION_RESEND_SECONDS = 60
+	ENUM_PASSWORD_RESET_TTL_MINUTES = 30
+	ENUM_TWO_FACTOR_CHALLENGE_TTL_MINUTES = 5
+	ENUM_TWO_FACTOR_RECOVERY_CODES = 10
+
+	ENUM_SCOPE_READ = "read"
+	ENUM_SCOPE_POST_WRITE = "post:write"
+	ENUM_SCOPE_LIKES_WRITE = "likes:write"
+	ENUM_PERSONAL_ACCESS_TOKEN_PREFIX = "tcpat_"
+	ENUM_PERSONAL_ACCESS_TOKEN_LIMIT = 20
+
+	ENUM_OIDC_PURPOSE_LOGIN = "login"
+	ENUM_OIDC_PURPOSE_LINK = "link"
+	ENUM_OIDC_STATE_TTL_MINUTES = 10
+	ENUM_OIDC_USERNAME_MAX_LENGTH = 15
+
+	ENUM_RATE_LIMIT_AUTH = "10/1m"
+	ENUM_RATE_LIMIT_POST = "30/15m"
+	ENUM_RATE_LIMIT_INTERACTION = "300/15m"
+	ENUM_RATE_LIMIT_LOOKUP = "60/1m"
+	ENUM_LOGIN_LOCKOUT_THRESHOLD = 5
+	ENUM_LOGIN_LOCKOUT_MINUTES = 1
+	ENUM_LOGIN_LOCKOUT_MAX_MINUTES = 60
+
+	ENUM_ACCOUNT_DELETION_GRACE_DAYS = 30
+	ENUM_ACCOUNT_PURGE_INTERVAL_MINUTES = 60
+	ENUM_ACCOUNT_PURGE_BATCH_SIZE = 50
+
 	DB = "db"
 	JWTService = "JWTService"
+	NotificationService = "NotificationService"
+	SessionService = "SessionService"
+	Mailer = "Mailer"
+	AccountService = "AccountService"
+	TwoFactorService = "TwoFactorService"
+	RateLimitStore = "RateLimitStore"
+	Storage = "Storage"
 )
\ No newline at end of file
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AccountController interface {
+		DeleteAccount(ctx *gin.Context)
+		RestoreAccount(ctx *gin.Context)
+	}
+
+	accountController struct {
+		accountService service.AccountService
+	}
+)
+
+func NewAccountController(as service.AccountService) AccountController {
+	return &accountController{
+		accountService: as,
+	}
+}
+
+func (c *accountController) DeleteAccount(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.AccountDeleteRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ACCOUNT_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.accountService.DeleteAccount(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_ACCOUNT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_ACCOUNT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *accountController) RestoreAccount(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	if err := c.accountService.RestoreAccount(ctx.Request.Context(), userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESTORE_ACCOUNT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESTORE_ACCOUNT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
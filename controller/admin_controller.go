@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AdminController interface {
+		GetUsers(ctx *gin.Context)
+		SuspendUser(ctx *gin.Context)
+		UnsuspendUser(ctx *gin.Context)
+		ForceDeletePost(ctx *gin.Context)
+	}
+
+	adminController struct {
+		adminService service.AdminService
+	}
+)
+
+func NewAdminController(as service.AdminService) AdminController {
+	return &adminController{
+		adminService: as,
+	}
+}
+
+func (c *adminController) GetUsers(ctx *gin.Context) {
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.adminService.GetUsers(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USERS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_USERS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *adminController) SuspendUser(ctx *gin.Context) {
+	adminId := ctx.GetString("user_id")
+	userId := ctx.Param("user_id")
+
+	err := c.adminService.SuspendUser(ctx.Request.Context(), adminId, userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SUSPEND_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SUSPEND_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *adminController) UnsuspendUser(ctx *gin.Context) {
+	userId := ctx.Param("user_id")
+
+	err := c.adminService.UnsuspendUser(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNSUSPEND_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNSUSPEND_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *adminController) ForceDeletePost(ctx *gin.Context) {
+	postIdStr := ctx.Param("post_id")
+	postId, err := strconv.ParseUint(postIdStr, 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.adminService.ForceDeletePost(ctx.Request.Context(), postId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FORCE_DELETE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORCE_DELETE, nil)
+	ctx.JSON(http.StatusOK, res)
+}
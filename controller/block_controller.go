@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	BlockController interface {
+		BlockUser(ctx *gin.Context)
+		UnblockUser(ctx *gin.Context)
+		GetBlocks(ctx *gin.Context)
+		MuteUser(ctx *gin.Context)
+		UnmuteUser(ctx *gin.Context)
+		GetMutes(ctx *gin.Context)
+	}
+
+	blockController struct {
+		blockService service.BlockService
+	}
+)
+
+func NewBlockController(bs service.BlockService) BlockController {
+	return &blockController{
+		blockService: bs,
+	}
+}
+
+func (c *blockController) BlockUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.blockService.BlockUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_BLOCK_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_BLOCK_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *blockController) UnblockUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.blockService.UnblockUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNBLOCK_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNBLOCK_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *blockController) GetBlocks(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.blockService.GetBlocks(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_BLOCKS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_BLOCKS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *blockController) MuteUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.blockService.MuteUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_MUTE_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_MUTE_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *blockController) UnmuteUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.blockService.UnmuteUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNMUTE_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNMUTE_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *blockController) GetMutes(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.blockService.GetMutes(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_MUTES, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_MUTES,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
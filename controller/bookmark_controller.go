@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	BookmarkController interface {
+		BookmarkPostById(ctx *gin.Context)
+		UnbookmarkPostById(ctx *gin.Context)
+		GetBookmarks(ctx *gin.Context)
+	}
+
+	bookmarkController struct {
+		bookmarkService service.BookmarkService
+	}
+)
+
+func NewBookmarkController(bookmarkService service.BookmarkService) BookmarkController {
+	return &bookmarkController{
+		bookmarkService: bookmarkService,
+	}
+}
+
+func (c *bookmarkController) BookmarkPostById(ctx *gin.Context) {
+	postId := ctx.Param("post_id")
+	userId := ctx.GetString("user_id")
+
+	postIdUint, err := strconv.ParseUint(postId, 10, 64)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err = c.bookmarkService.BookmarkPostById(ctx, postIdUint, userId)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_BOOKMARK_POST, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_BOOKMARK_POST, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *bookmarkController) UnbookmarkPostById(ctx *gin.Context) {
+	postId := ctx.Param("post_id")
+	userId := ctx.GetString("user_id")
+
+	postIdUint, err := strconv.ParseUint(postId, 10, 64)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	err = c.bookmarkService.UnbookmarkPostById(ctx, postIdUint, userId)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNBOOKMARK_POST, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNBOOKMARK_POST, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *bookmarkController) GetBookmarks(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.bookmarkService.GetBookmarks(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_BOOKMARKS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_BOOKMARKS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
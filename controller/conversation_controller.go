@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ConversationController interface {
+		CreateConversation(ctx *gin.Context)
+		GetConversations(ctx *gin.Context)
+		GetConversationById(ctx *gin.Context)
+		SendMessage(ctx *gin.Context)
+		GetMessages(ctx *gin.Context)
+		MarkAsRead(ctx *gin.Context)
+	}
+
+	conversationController struct {
+		conversationService service.ConversationService
+	}
+)
+
+func NewConversationController(cs service.ConversationService) ConversationController {
+	return &conversationController{
+		conversationService: cs,
+	}
+}
+
+func (c *conversationController) CreateConversation(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.ConversationCreateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.conversationService.CreateConversation(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_CONVERSATION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_CONVERSATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *conversationController) GetConversations(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.conversationService.GetConversations(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATIONS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_CONVERSATIONS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *conversationController) GetConversationById(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	conversationId, err := strconv.ParseUint(ctx.Param("conversation_id"), 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.conversationService.GetConversationById(ctx.Request.Context(), userId, conversationId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_CONVERSATION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *conversationController) SendMessage(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	conversationId, err := strconv.ParseUint(ctx.Param("conversation_id"), 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var req dto.MessageCreateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.conversationService.SendMessage(ctx.Request.Context(), userId, conversationId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SEND_MESSAGE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SEND_MESSAGE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *conversationController) GetMessages(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	conversationId, err := strconv.ParseUint(ctx.Param("conversation_id"), 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var req dto.MessageCursorRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.conversationService.GetMessages(ctx.Request.Context(), userId, conversationId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_MESSAGES, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_MESSAGES,
+		Data:    result.Data,
+		Meta:    result.MessageCursorResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *conversationController) MarkAsRead(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	conversationId, err := strconv.ParseUint(ctx.Param("conversation_id"), 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// an empty body marks the conversation as read up to the latest message
+	var req dto.ConversationReadRequest
+	if err := ctx.ShouldBind(&req); err != nil && !errors.Is(err, io.EOF) {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.conversationService.MarkAsRead(ctx.Request.Context(), userId, conversationId, req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_READ_CONVERSATION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_READ_CONVERSATION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	FollowController interface {
+		FollowUser(ctx *gin.Context)
+		UnfollowUser(ctx *gin.Context)
+		GetFollowers(ctx *gin.Context)
+		GetFollowing(ctx *gin.Context)
+	}
+
+	followController struct {
+		followService service.FollowService
+	}
+)
+
+func NewFollowController(fs service.FollowService) FollowController {
+	return &followController{
+		followService: fs,
+	}
+}
+
+func (c *followController) FollowUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.followService.FollowUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FOLLOW_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FOLLOW_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *followController) UnfollowUser(ctx *gin.Context) {
+	username := ctx.Param("username")
+	userId := ctx.GetString("user_id")
+
+	err := c.followService.UnfollowUser(ctx.Request.Context(), userId, username)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNFOLLOW_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNFOLLOW_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *followController) GetFollowers(ctx *gin.Context) {
+	username := ctx.Param("username")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.followService.GetFollowers(ctx.Request.Context(), username, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FOLLOWERS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_FOLLOWERS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *followController) GetFollowing(ctx *gin.Context) {
+	username := ctx.Param("username")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.followService.GetFollowing(ctx.Request.Context(), username, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FOLLOWING, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_FOLLOWING,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
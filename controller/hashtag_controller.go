@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	HashtagController interface {
+		GetPostsByHashtag(ctx *gin.Context)
+		GetTrends(ctx *gin.Context)
+	}
+
+	hashtagController struct {
+		hashtagService service.HashtagService
+	}
+)
+
+func NewHashtagController(hs service.HashtagService) HashtagController {
+	return &hashtagController{
+		hashtagService: hs,
+	}
+}
+
+func (c *hashtagController) GetPostsByHashtag(ctx *gin.Context) {
+	tag := ctx.Param("tag")
+	viewerId := ctx.GetString("user_id")
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.hashtagService.GetPostsByHashtag(ctx.Request.Context(), viewerId, tag, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_HASHTAG_POSTS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_HASHTAG_POSTS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *hashtagController) GetTrends(ctx *gin.Context) {
+	var req dto.TrendsRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.hashtagService.GetTrends(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TRENDS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TRENDS, result)
+	ctx.JSON(http.StatusOK, res)
+}
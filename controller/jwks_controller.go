@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	JWKSController interface {
+		GetJWKS(ctx *gin.Context)
+	}
+
+	jwksController struct {
+		jwtService service.JWTService
+	}
+)
+
+func NewJWKSController(js service.JWTService) JWKSController {
+	return &jwksController{
+		jwtService: js,
+	}
+}
+
+// GetJWKS answers with a bare key set rather than the usual response
+// envelope, that is the shape JWT libraries expect to fetch.
+func (c *jwksController) GetJWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.jwtService.GetJWKS())
+}
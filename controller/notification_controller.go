@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	NotificationController interface {
+		GetNotifications(ctx *gin.Context)
+		GetUnreadCount(ctx *gin.Context)
+		MarkAsRead(ctx *gin.Context)
+	}
+
+	notificationController struct {
+		notificationService service.NotificationService
+	}
+)
+
+func NewNotificationController(ns service.NotificationService) NotificationController {
+	return &notificationController{
+		notificationService: ns,
+	}
+}
+
+func (c *notificationController) GetNotifications(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NOTIFICATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.notificationService.GetNotifications(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NOTIFICATIONS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_NOTIFICATIONS,
+		Data:    result.Data,
+		Meta:    result.NotificationMetaResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) GetUnreadCount(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.notificationService.GetUnreadCount(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_UNREAD_COUNT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_UNREAD_COUNT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *notificationController) MarkAsRead(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	// an empty body marks every notification as read
+	var req dto.NotificationReadRequest
+	if err := ctx.ShouldBind(&req); err != nil && !errors.Is(err, io.EOF) {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_NOTIFICATION_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.notificationService.MarkAsRead(ctx.Request.Context(), userId, req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_READ_NOTIFICATIONS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_READ_NOTIFICATIONS, nil)
+	ctx.JSON(http.StatusOK, res)
+}
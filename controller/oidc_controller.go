@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	OIDCController interface {
+		GetProviders(ctx *gin.Context)
+		Authorize(ctx *gin.Context)
+		AuthorizeLink(ctx *gin.Context)
+		Login(ctx *gin.Context)
+		Link(ctx *gin.Context)
+	}
+
+	oidcController struct {
+		oidcService service.OIDCService
+	}
+)
+
+func NewOIDCController(os service.OIDCService) OIDCController {
+	return &oidcController{
+		oidcService: os,
+	}
+}
+
+func (c *oidcController) GetProviders(ctx *gin.Context) {
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_OIDC_PROVIDERS, c.oidcService.GetProviders())
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *oidcController) Authorize(ctx *gin.Context) {
+	result, err := c.oidcService.Authorize(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OIDC_AUTHORIZE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_OIDC_AUTHORIZE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *oidcController) AuthorizeLink(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	result, err := c.oidcService.AuthorizeLink(ctx.Request.Context(), userId, ctx.Param("provider"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OIDC_AUTHORIZE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_OIDC_AUTHORIZE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *oidcController) Login(ctx *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	meta := dto.SessionMetadata{
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	}
+
+	result, err := c.oidcService.Login(ctx.Request.Context(), ctx.Param("provider"), req, meta)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OIDC_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if result.TwoFactorRequired {
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_TWO_FACTOR_LOGIN, result)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *oidcController) Link(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.OIDCCallbackRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.oidcService.Link(ctx.Request.Context(), userId, ctx.Param("provider"), req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OIDC_LINK, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_OIDC_LINK, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PasswordController interface {
+		ForgotPassword(ctx *gin.Context)
+		ResetPassword(ctx *gin.Context)
+		ChangePassword(ctx *gin.Context)
+	}
+
+	passwordController struct {
+		passwordService service.PasswordService
+	}
+)
+
+func NewPasswordController(ps service.PasswordService) PasswordController {
+	return &passwordController{
+		passwordService: ps,
+	}
+}
+
+func (c *passwordController) ForgotPassword(ctx *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_PASSWORD_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	c.passwordService.ForgotPassword(ctx.Request.Context(), req)
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORGOT_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *passwordController) ResetPassword(ctx *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_PASSWORD_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.passwordService.ResetPassword(ctx.Request.Context(), req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESET_PASSWORD, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESET_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *passwordController) ChangePassword(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+	sessionId := ctx.GetString("session_id")
+
+	var req dto.ChangePasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_PASSWORD_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.passwordService.ChangePassword(ctx.Request.Context(), userId, sessionId, req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_PASSWORD, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHANGE_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
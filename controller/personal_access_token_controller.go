@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PersonalAccessTokenController interface {
+		CreatePersonalAccessToken(ctx *gin.Context)
+		GetPersonalAccessTokens(ctx *gin.Context)
+		RevokePersonalAccessToken(ctx *gin.Context)
+	}
+
+	personalAccessTokenController struct {
+		personalAccessTokenService service.PersonalAccessTokenService
+	}
+)
+
+func NewPersonalAccessTokenController(pats service.PersonalAccessTokenService) PersonalAccessTokenController {
+	return &personalAccessTokenController{
+		personalAccessTokenService: pats,
+	}
+}
+
+func (c *personalAccessTokenController) CreatePersonalAccessToken(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.PersonalAccessTokenCreateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.personalAccessTokenService.CreatePersonalAccessToken(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_PERSONAL_ACCESS_TOKEN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_PERSONAL_ACCESS_TOKEN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *personalAccessTokenController) GetPersonalAccessTokens(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	result, err := c.personalAccessTokenService.GetPersonalAccessTokens(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_PERSONAL_ACCESS_TOKENS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_PERSONAL_ACCESS_TOKENS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *personalAccessTokenController) RevokePersonalAccessToken(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	tokenId := ctx.Param("token_id")
+
+	if err := c.personalAccessTokenService.RevokePersonalAccessToken(ctx.Request.Context(), userId, tokenId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REVOKE_PERSONAL_ACCESS_TOKEN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVOKE_PERSONAL_ACCESS_TOKEN, nil)
+	ctx.JSON(http.StatusOK, res)
+}
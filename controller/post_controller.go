@@ -16,7 +16,12 @@ type (
 		GetPostById(ctx *gin.Context)
 		DeletePostById(ctx *gin.Context)
 		UpdatePostById(ctx *gin.Context)
+		GetPostHistory(ctx *gin.Context)
 		GetAllPosts(ctx *gin.Context)
+		GetTimeline(ctx *gin.Context)
+		RepostPostById(ctx *gin.Context)
+		UnrepostPostById(ctx *gin.Context)
+		VotePoll(ctx *gin.Context)
 	}
 
 	postController struct {
@@ -67,7 +72,8 @@ func (c *postController) GetPostById(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.postService.GetPostById(ctx.Request.Context(), postId, req)
+	viewerId := ctx.GetString("user_id")
+	result, err := c.postService.GetPostById(ctx.Request.Context(), viewerId, postId, req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -85,6 +91,8 @@ func (c *postController) GetPostById(ctx *gin.Context) {
 }
 
 func (c *postController) DeletePostById(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
 	postIdStr := ctx.Param("post_id")
 	postId, err := strconv.ParseUint(postIdStr, 10, 64)
 	if err != nil {
@@ -93,7 +101,12 @@ func (c *postController) DeletePostById(ctx *gin.Context) {
 		return
 	}
 
-	err = c.postService.DeletePostById(ctx.Request.Context(), postId)
+	err = c.postService.DeletePostById(ctx.Request.Context(), userId, postId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_POST, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
 
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_POST, nil)
 	ctx.JSON(http.StatusOK, res)
@@ -128,6 +141,26 @@ func (c *postController) UpdatePostById(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, res)
 }
 
+func (c *postController) GetPostHistory(ctx *gin.Context) {
+	postIdStr := ctx.Param("post_id")
+	postId, err := strconv.ParseUint(postIdStr, 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.postService.GetPostHistory(ctx.Request.Context(), postId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_HISTORY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_POST_HISTORY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
 func (c *postController) GetAllPosts(ctx *gin.Context) {
 	var req dto.PaginationRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -136,7 +169,8 @@ func (c *postController) GetAllPosts(ctx *gin.Context) {
 		return
 	}
 
-	posts, err := c.postService.GetAllPosts(ctx.Request.Context(), req)
+	viewerId := ctx.GetString("user_id")
+	posts, err := c.postService.GetAllPosts(ctx.Request.Context(), viewerId, req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ALL_POSTS, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -152,3 +186,101 @@ func (c *postController) GetAllPosts(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *postController) GetTimeline(ctx *gin.Context) {
+	var req dto.TimelinePaginationRequest
+	userId := ctx.GetString("user_id")
+
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.postService.GetTimeline(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TIMELINE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_TIMELINE,
+		Data:    result.Data,
+		Meta:    result.TimelineMetaResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *postController) RepostPostById(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	postIdStr := ctx.Param("post_id")
+	postId, err := strconv.ParseUint(postIdStr, 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.postService.RepostPostById(ctx.Request.Context(), postId, userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REPOST_POST, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REPOST_POST, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *postController) UnrepostPostById(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	postIdStr := ctx.Param("post_id")
+	postId, err := strconv.ParseUint(postIdStr, 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.postService.UnrepostPostById(ctx.Request.Context(), postId, userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNREPOST_POST, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNREPOST_POST, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *postController) VotePoll(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	postIdStr := ctx.Param("post_id")
+	postId, err := strconv.ParseUint(postIdStr, 10, 64)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_ID, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	var req dto.PollVoteRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.postService.VotePoll(ctx.Request.Context(), userId, postId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VOTE_POLL, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VOTE_POLL, result)
+	ctx.JSON(http.StatusOK, res)
+}
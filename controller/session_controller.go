@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SessionController interface {
+		Refresh(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+		LogoutAll(ctx *gin.Context)
+		GetSessions(ctx *gin.Context)
+		RevokeSession(ctx *gin.Context)
+	}
+
+	sessionController struct {
+		sessionService service.SessionService
+	}
+)
+
+func NewSessionController(ss service.SessionService) SessionController {
+	return &sessionController{
+		sessionService: ss,
+	}
+}
+
+func (c *sessionController) Refresh(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	meta := dto.SessionMetadata{
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	}
+
+	result, err := c.sessionService.Refresh(ctx.Request.Context(), req, meta)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REFRESH_TOKEN, err.Error(), nil)
+		ctx.JSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REFRESH_TOKEN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sessionController) Logout(ctx *gin.Context) {
+	sessionId := ctx.MustGet("session_id").(string)
+
+	if err := c.sessionService.Logout(ctx.Request.Context(), sessionId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sessionController) LogoutAll(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	if err := c.sessionService.LogoutAll(ctx.Request.Context(), userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sessionController) GetSessions(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	sessionId := ctx.GetString("session_id")
+
+	result, err := c.sessionService.GetSessions(ctx.Request.Context(), userId, sessionId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SESSIONS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SESSIONS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sessionController) RevokeSession(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+	sessionId := ctx.Param("session_id")
+
+	if err := c.sessionService.RevokeSession(ctx.Request.Context(), userId, sessionId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REVOKE_SESSION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVOKE_SESSION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
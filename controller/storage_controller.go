@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	StorageController interface {
+		GetFile(ctx *gin.Context)
+	}
+
+	storageController struct {
+		storage utils.Storage
+	}
+)
+
+func NewStorageController(storage utils.Storage) StorageController {
+	return &storageController{
+		storage: storage,
+	}
+}
+
+// GetFile serves files of the local storage to holders of a signed link,
+// other storages hand out links to their own servers.
+func (c *storageController) GetFile(ctx *gin.Context) {
+	local, ok := c.storage.(*utils.LocalStorage)
+	if !ok {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	filePath, expiresAt, err := local.Open(key, ctx.Query("expires"), ctx.Query("signature"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, res)
+		return
+	}
+
+	ctx.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(time.Until(expiresAt).Seconds())))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.File(filePath)
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	TwoFactorController interface {
+		Setup(ctx *gin.Context)
+		Confirm(ctx *gin.Context)
+		Disable(ctx *gin.Context)
+		Login(ctx *gin.Context)
+	}
+
+	twoFactorController struct {
+		twoFactorService service.TwoFactorService
+	}
+)
+
+func NewTwoFactorController(tfs service.TwoFactorService) TwoFactorController {
+	return &twoFactorController{
+		twoFactorService: tfs,
+	}
+}
+
+func (c *twoFactorController) Setup(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	result, err := c.twoFactorService.Setup(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SETUP_TWO_FACTOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SETUP_TWO_FACTOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *twoFactorController) Confirm(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.TwoFactorCodeRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TWO_FACTOR_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.twoFactorService.Confirm(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_TWO_FACTOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CONFIRM_TWO_FACTOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *twoFactorController) Disable(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	var req dto.TwoFactorDisableRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TWO_FACTOR_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.twoFactorService.Disable(ctx.Request.Context(), userId, req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DISABLE_TWO_FACTOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DISABLE_TWO_FACTOR, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *twoFactorController) Login(ctx *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TWO_FACTOR_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	meta := dto.SessionMetadata{
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	}
+
+	result, err := c.twoFactorService.Login(ctx.Request.Context(), req, meta)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
@@ -18,6 +19,9 @@ type (
 		UpdateUser(ctx *gin.Context)
 		CheckUsername(ctx *gin.Context)
 		GetUserPosts(ctx *gin.Context)
+		GetMentions(ctx *gin.Context)
+		VerifyEmail(ctx *gin.Context)
+		ResendVerification(ctx *gin.Context)
 	}
 
 	userController struct {
@@ -72,13 +76,30 @@ func (c *userController) Login(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.userService.Verify(ctx.Request.Context(), req)
+	meta := dto.SessionMetadata{
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	}
+
+	result, err := c.userService.Verify(ctx.Request.Context(), req, meta)
+	if errors.Is(err, dto.ErrAccountLocked) {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.JSON(http.StatusTooManyRequests, res)
+		return
+	}
+
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
 		return
 	}
 
+	if result.TwoFactorRequired {
+		res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_TWO_FACTOR_LOGIN, result)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, result)
 	ctx.JSON(http.StatusOK, res)
 }
@@ -156,7 +177,8 @@ func (c *userController) GetUserPosts(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.userService.GetUserPosts(ctx.Request.Context(), username, req)
+	viewerId := ctx.GetString("user_id")
+	result, err := c.userService.GetUserPosts(ctx.Request.Context(), viewerId, username, req)
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_POSTS, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -172,3 +194,68 @@ func (c *userController) GetUserPosts(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *userController) GetMentions(ctx *gin.Context) {
+	userId := ctx.MustGet("user_id").(string)
+
+	var req dto.PaginationRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_POST_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.userService.GetMentions(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_MENTIONS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_MENTIONS,
+		Data:    result.Data,
+		Meta:    result.PaginationResponse,
+	}
+
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userController) VerifyEmail(ctx *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_USER_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.userService.VerifyEmail(ctx.Request.Context(), req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_EMAIL, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VERIFY_EMAIL, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userController) ResendVerification(ctx *gin.Context) {
+	userId := ctx.GetString("user_id")
+
+	err := c.userService.ResendVerification(ctx.Request.Context(), userId)
+	if errors.Is(err, dto.ErrVerificationThrottled) {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESEND_VERIFICATION, err.Error(), nil)
+		ctx.JSON(http.StatusTooManyRequests, res)
+		return
+	}
+
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RESEND_VERIFICATION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESEND_VERIFICATION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_ACCOUNT_DATA_FROM_BODY = "failed get data from body"
+	MESSAGE_FAILED_DELETE_ACCOUNT             = "failed delete account"
+	MESSAGE_FAILED_RESTORE_ACCOUNT            = "failed restore account"
+
+	// Success
+	MESSAGE_SUCCESS_DELETE_ACCOUNT  = "success delete account"
+	MESSAGE_SUCCESS_RESTORE_ACCOUNT = "success restore account"
+)
+
+var (
+	ErrDeleteAccount         = errors.New("failed to delete account")
+	ErrAccountNotDeleted     = errors.New("account is not scheduled for deletion")
+	ErrAccountAlreadyDeleted = errors.New("account already scheduled for deletion")
+	ErrRestoreAccount        = errors.New("failed to restore account")
+)
+
+type (
+	AccountDeleteRequest struct {
+		Password string `json:"password" form:"password" binding:"required"`
+	}
+
+	AccountDeleteResponse struct {
+		DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+	}
+)
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_USERS      = "failed get users"
+	MESSAGE_FAILED_SUSPEND_USER   = "failed suspend user"
+	MESSAGE_FAILED_UNSUSPEND_USER = "failed unsuspend user"
+	MESSAGE_FAILED_FORCE_DELETE   = "failed force delete post"
+
+	// Success
+	MESSAGE_SUCCESS_GET_USERS      = "success get users"
+	MESSAGE_SUCCESS_SUSPEND_USER   = "success suspend user"
+	MESSAGE_SUCCESS_UNSUSPEND_USER = "success unsuspend user"
+	MESSAGE_SUCCESS_FORCE_DELETE   = "success force delete post"
+)
+
+var (
+	ErrGetUsers         = errors.New("failed to get users")
+	ErrSuspendSelf      = errors.New("cannot suspend yourself")
+	ErrSuspendAdmin     = errors.New("cannot suspend an admin")
+	ErrAlreadySuspended = errors.New("user already suspended")
+	ErrNotSuspended     = errors.New("user not suspended")
+	ErrSuspendUser      = errors.New("failed to suspend user")
+	ErrForceDeletePost  = errors.New("failed to force delete post")
+)
+
+type (
+	AdminUserResponse struct {
+		UserResponse
+		Role        string     `json:"role"`
+		SuspendedAt *time.Time `json:"suspended_at"`
+		CreatedAt   time.Time  `json:"created_at"`
+	}
+
+	AdminUserPaginationResponse struct {
+		Data []AdminUserResponse `json:"data"`
+		PaginationResponse
+	}
+)
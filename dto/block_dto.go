@@ -0,0 +1,38 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_BLOCK_USER   = "failed block user"
+	MESSAGE_FAILED_UNBLOCK_USER = "failed unblock user"
+	MESSAGE_FAILED_GET_BLOCKS   = "failed get blocked users"
+	MESSAGE_FAILED_MUTE_USER    = "failed mute user"
+	MESSAGE_FAILED_UNMUTE_USER  = "failed unmute user"
+	MESSAGE_FAILED_GET_MUTES    = "failed get muted users"
+
+	// Success
+	MESSAGE_SUCCESS_BLOCK_USER   = "success block user"
+	MESSAGE_SUCCESS_UNBLOCK_USER = "success unblock user"
+	MESSAGE_SUCCESS_GET_BLOCKS   = "success get blocked users"
+	MESSAGE_SUCCESS_MUTE_USER    = "success mute user"
+	MESSAGE_SUCCESS_UNMUTE_USER  = "success unmute user"
+	MESSAGE_SUCCESS_GET_MUTES    = "success get muted users"
+)
+
+var (
+	ErrBlockUser      = errors.New("failed to block user")
+	ErrUnblockUser    = errors.New("failed to unblock user")
+	ErrBlockSelf      = errors.New("cannot block yourself")
+	ErrAlreadyBlocked = errors.New("user already blocked")
+	ErrNotBlocked     = errors.New("user not blocked")
+	ErrGetBlocks      = errors.New("failed to get blocked users")
+	ErrMuteUser       = errors.New("failed to mute user")
+	ErrUnmuteUser     = errors.New("failed to unmute user")
+	ErrMuteSelf       = errors.New("cannot mute yourself")
+	ErrAlreadyMuted   = errors.New("user already muted")
+	ErrNotMuted       = errors.New("user not muted")
+	ErrGetMutes       = errors.New("failed to get muted users")
+	ErrCheckBlocked   = errors.New("failed to check blocked user")
+	ErrBlocked        = errors.New("action not allowed, one of the users has blocked the other")
+)
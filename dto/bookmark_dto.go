@@ -0,0 +1,23 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_BOOKMARK_POST   = "failed bookmark post"
+	MESSAGE_FAILED_UNBOOKMARK_POST = "failed unbookmark post"
+	MESSAGE_FAILED_GET_BOOKMARKS   = "failed get bookmarks"
+
+	// Success
+	MESSAGE_SUCCESS_BOOKMARK_POST   = "success bookmark post"
+	MESSAGE_SUCCESS_UNBOOKMARK_POST = "success unbookmark post"
+	MESSAGE_SUCCESS_GET_BOOKMARKS   = "success get bookmarks"
+)
+
+var (
+	ErrBookmarkPostById    = errors.New("failed to bookmark post")
+	ErrAlreadyBookmarked   = errors.New("post already bookmarked")
+	ErrCheckBookmarkedPost = errors.New("failed to check bookmarked post")
+	ErrUnbookmarkPostById  = errors.New("failed to unbookmark post")
+	ErrGetBookmarks        = errors.New("failed to get bookmarks")
+)
@@ -0,0 +1,116 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_CONVERSATION_DATA_FROM_BODY = "failed get data from body"
+	MESSAGE_FAILED_GET_CONVERSATION_ID             = "failed get conversation id"
+	MESSAGE_FAILED_CREATE_CONVERSATION             = "failed create conversation"
+	MESSAGE_FAILED_GET_CONVERSATIONS               = "failed get conversations"
+	MESSAGE_FAILED_GET_CONVERSATION                = "failed get conversation"
+	MESSAGE_FAILED_SEND_MESSAGE                    = "failed send message"
+	MESSAGE_FAILED_GET_MESSAGES                    = "failed get messages"
+	MESSAGE_FAILED_READ_CONVERSATION               = "failed mark conversation as read"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_CONVERSATION = "success create conversation"
+	MESSAGE_SUCCESS_GET_CONVERSATIONS   = "success get conversations"
+	MESSAGE_SUCCESS_GET_CONVERSATION    = "success get conversation"
+	MESSAGE_SUCCESS_SEND_MESSAGE        = "success send message"
+	MESSAGE_SUCCESS_GET_MESSAGES        = "success get messages"
+	MESSAGE_SUCCESS_READ_CONVERSATION   = "success mark conversation as read"
+)
+
+var (
+	ErrCreateConversation  = errors.New("failed to create conversation")
+	ErrConversationSelf    = errors.New("cannot start a conversation with yourself")
+	ErrTooManyParticipants = errors.New("too many participants in conversation")
+	ErrGetConversation     = errors.New("conversation not found")
+	ErrGetConversations    = errors.New("failed to get conversations")
+	ErrNotParticipant      = errors.New("user is not a participant of the conversation")
+	ErrSendMessage         = errors.New("failed to send message")
+	ErrGetMessages         = errors.New("failed to get messages")
+	ErrGetMessageById      = errors.New("message not found")
+	ErrReadConversation    = errors.New("failed to mark conversation as read")
+)
+
+type (
+	ConversationCreateRequest struct {
+		Usernames []string `json:"usernames" form:"usernames" binding:"required,min=1"`
+		Name      *string  `json:"name" form:"name"`
+	}
+
+	MessageCreateRequest struct {
+		Text string `json:"text" form:"text" binding:"required"`
+	}
+
+	// ConversationReadRequest marks messages up to MessageID as read, the
+	// latest message when it is omitted.
+	ConversationReadRequest struct {
+		MessageID *uint64 `json:"message_id" form:"message_id"`
+	}
+
+	// MessageCursorRequest pages backwards through history, Cursor is the
+	// oldest message id already loaded.
+	MessageCursorRequest struct {
+		Cursor uint64 `form:"cursor"`
+		Limit  int    `form:"limit"`
+	}
+
+	MessageCursorResponse struct {
+		NextCursor *uint64 `json:"next_cursor"`
+		HasMore    bool    `json:"has_more"`
+	}
+
+	MessageResponse struct {
+		ID             uint64       `json:"id"`
+		ConversationID uint64       `json:"conversation_id"`
+		Text           string       `json:"text"`
+		Sender         UserResponse `json:"sender"`
+		CreatedAt      time.Time    `json:"created_at"`
+	}
+
+	ParticipantResponse struct {
+		User              UserResponse `json:"user"`
+		LastReadMessageID *uint64      `json:"last_read_message_id"`
+		LastReadAt        *time.Time   `json:"last_read_at"`
+	}
+
+	ConversationResponse struct {
+		ID            uint64                `json:"id"`
+		Name          *string               `json:"name"`
+		IsGroup       bool                  `json:"is_group"`
+		Participants  []ParticipantResponse `json:"participants"`
+		LastMessage   *MessageResponse      `json:"last_message"`
+		LastMessageAt time.Time             `json:"last_message_at"`
+		UnreadCount   int64                 `json:"unread_count"`
+	}
+
+	ConversationPaginationResponse struct {
+		Data []ConversationResponse `json:"data"`
+		PaginationResponse
+	}
+
+	MessagePaginationResponse struct {
+		Data []MessageResponse `json:"data"`
+		MessageCursorResponse
+	}
+
+	GetAllConversationsRepositoryResponse struct {
+		Conversations []entity.Conversation `json:"conversations"`
+		PaginationResponse
+	}
+)
+
+func (r *MessageCursorRequest) Default() {
+	if r.Limit <= 0 || r.Limit > constants.ENUM_MESSAGE_PAGE_LIMIT {
+		r.Limit = constants.ENUM_MESSAGE_PAGE_LIMIT
+	}
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_FOLLOW_USER   = "failed follow user"
+	MESSAGE_FAILED_UNFOLLOW_USER = "failed unfollow user"
+	MESSAGE_FAILED_GET_FOLLOWERS = "failed get followers"
+	MESSAGE_FAILED_GET_FOLLOWING = "failed get following"
+
+	// Success
+	MESSAGE_SUCCESS_FOLLOW_USER   = "success follow user"
+	MESSAGE_SUCCESS_UNFOLLOW_USER = "success unfollow user"
+	MESSAGE_SUCCESS_GET_FOLLOWERS = "success get followers"
+	MESSAGE_SUCCESS_GET_FOLLOWING = "success get following"
+)
+
+var (
+	ErrFollowUser       = errors.New("failed to follow user")
+	ErrUnfollowUser     = errors.New("failed to unfollow user")
+	ErrFollowSelf       = errors.New("cannot follow yourself")
+	ErrAlreadyFollowing = errors.New("user already followed")
+	ErrNotFollowing     = errors.New("user not followed")
+	ErrGetFollowers     = errors.New("failed to get followers")
+	ErrGetFollowing     = errors.New("failed to get following")
+)
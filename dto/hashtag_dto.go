@@ -0,0 +1,31 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_HASHTAG_POSTS = "failed get hashtag posts"
+	MESSAGE_FAILED_GET_TRENDS        = "failed get trends"
+
+	// Success
+	MESSAGE_SUCCESS_GET_HASHTAG_POSTS = "success get hashtag posts"
+	MESSAGE_SUCCESS_GET_TRENDS        = "success get trends"
+)
+
+var (
+	ErrSaveHashtags    = errors.New("failed to save hashtags")
+	ErrGetHashtagPosts = errors.New("failed to get hashtag posts")
+	ErrGetTrends       = errors.New("failed to get trends")
+)
+
+type (
+	TrendsRequest struct {
+		Limit int `form:"limit"`
+	}
+
+	TrendResponse struct {
+		Name       string  `json:"name"`
+		TotalPosts int64   `json:"total_posts"`
+		Score      float64 `json:"score"`
+	}
+)
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"errors"
+)
+
+var (
+	ErrNoActiveSigningKey = errors.New("no active jwt signing key")
+	ErrInvalidIssuer      = errors.New("invalid token issuer")
+)
+
+type (
+	// JWK is a public signing key as described in RFC 7517, RSA keys fill
+	// N and E while Ed25519 keys fill Curve and X.
+	JWK struct {
+		KeyType   string `json:"kty"`
+		KeyID     string `json:"kid"`
+		Algorithm string `json:"alg"`
+		Use       string `json:"use"`
+		N         string `json:"n,omitempty"`
+		E         string `json:"e,omitempty"`
+		Curve     string `json:"crv,omitempty"`
+		X         string `json:"x,omitempty"`
+	}
+
+	JWKSResponse struct {
+		Keys []JWK `json:"keys"`
+	}
+)
@@ -0,0 +1,26 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_MENTIONS = "failed get mentions"
+
+	// Success
+	MESSAGE_SUCCESS_GET_MENTIONS = "success get mentions"
+)
+
+var (
+	ErrSaveMentions = errors.New("failed to save mentions")
+	ErrGetMentions  = errors.New("failed to get mentions")
+)
+
+type (
+	// MentionResponse offsets are rune offsets into the post text
+	MentionResponse struct {
+		UserID   string `json:"user_id"`
+		UserName string `json:"username"`
+		Start    int    `json:"start"`
+		End      int    `json:"end"`
+	}
+)
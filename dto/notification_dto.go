@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_NOTIFICATIONS               = "failed get notifications"
+	MESSAGE_FAILED_GET_UNREAD_COUNT                = "failed get unread notifications count"
+	MESSAGE_FAILED_READ_NOTIFICATIONS              = "failed mark notifications as read"
+	MESSAGE_FAILED_GET_NOTIFICATION_DATA_FROM_BODY = "failed get notification data from body"
+
+	// Success
+	MESSAGE_SUCCESS_GET_NOTIFICATIONS  = "success get notifications"
+	MESSAGE_SUCCESS_GET_UNREAD_COUNT   = "success get unread notifications count"
+	MESSAGE_SUCCESS_READ_NOTIFICATIONS = "success mark notifications as read"
+)
+
+var (
+	ErrCreateNotification = errors.New("failed to create notification")
+	ErrDeleteNotification = errors.New("failed to delete notification")
+	ErrGetNotifications   = errors.New("failed to get notifications")
+	ErrGetUnreadCount     = errors.New("failed to get unread notifications count")
+	ErrReadNotifications  = errors.New("failed to mark notifications as read")
+)
+
+type (
+	NotificationReadRequest struct {
+		GroupKey string `json:"group_key" form:"group_key"`
+	}
+
+	NotificationResponse struct {
+		GroupKey    string         `json:"group_key"`
+		Type        string         `json:"type"`
+		Message     string         `json:"message"`
+		Actors      []UserResponse `json:"actors"`
+		TotalActors int64          `json:"total_actors"`
+		Post        *PostResponse  `json:"post,omitempty"`
+		IsRead      bool           `json:"is_read"`
+		CreatedAt   time.Time      `json:"created_at"`
+	}
+
+	NotificationMetaResponse struct {
+		PaginationResponse
+		UnreadCount int64 `json:"unread_count"`
+	}
+
+	NotificationPaginationResponse struct {
+		Data []NotificationResponse `json:"data"`
+		NotificationMetaResponse
+	}
+
+	NotificationUnreadResponse struct {
+		UnreadCount int64 `json:"unread_count"`
+	}
+
+	// NotificationGroup is one row of the grouped notifications query
+	NotificationGroup struct {
+		GroupKey    string
+		Type        string
+		PostID      *uint64
+		IsRead      bool
+		TotalActors int64
+		LatestAt    time.Time
+		Actors      []entity.Notification `gorm:"-"`
+	}
+
+	GetAllNotificationsRepositoryResponse struct {
+		Groups []NotificationGroup
+		PaginationResponse
+	}
+)
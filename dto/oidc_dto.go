@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"errors"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_OIDC_PROVIDERS = "failed get login providers"
+	MESSAGE_FAILED_OIDC_AUTHORIZE     = "failed start provider login"
+	MESSAGE_FAILED_OIDC_LOGIN         = "failed provider login"
+	MESSAGE_FAILED_OIDC_LINK          = "failed link provider account"
+
+	// Success
+	MESSAGE_SUCCESS_GET_OIDC_PROVIDERS = "success get login providers"
+	MESSAGE_SUCCESS_OIDC_AUTHORIZE     = "success start provider login"
+	MESSAGE_SUCCESS_OIDC_LINK          = "success link provider account"
+)
+
+var (
+	ErrOIDCProviderNotFound = errors.New("login provider not found")
+	ErrOIDCAuthorize        = errors.New("failed to start provider login")
+	ErrOIDCInvalidState     = errors.New("provider login invalid or expired, start again")
+	ErrOIDCLogin            = errors.New("failed to sign in with provider")
+	ErrOIDCEmailInUse       = errors.New("email already registered, sign in with your password and link the provider instead")
+	ErrOIDCIdentityInUse    = errors.New("provider account already linked to another user")
+	ErrGenerateUsername     = errors.New("failed to generate a username")
+)
+
+type (
+	OIDCAuthorizeResponse struct {
+		AuthorizationURL string `json:"authorization_url"`
+		State            string `json:"state"`
+	}
+
+	// OIDCCallbackRequest carries the code and state the provider appended to
+	// the redirect url
+	OIDCCallbackRequest struct {
+		Code  string `json:"code" form:"code" binding:"required"`
+		State string `json:"state" form:"state" binding:"required"`
+	}
+)
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"errors"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_PASSWORD_DATA_FROM_BODY = "failed get data from body"
+	MESSAGE_FAILED_RESET_PASSWORD              = "failed reset password"
+	MESSAGE_FAILED_CHANGE_PASSWORD             = "failed change password"
+
+	// Success
+	MESSAGE_SUCCESS_FORGOT_PASSWORD = "if the account exists, a reset link has been sent to its email"
+	MESSAGE_SUCCESS_RESET_PASSWORD  = "success reset password"
+	MESSAGE_SUCCESS_CHANGE_PASSWORD = "success change password"
+)
+
+var (
+	ErrInvalidPasswordReset = errors.New("reset link invalid or expired")
+	ErrResetPassword        = errors.New("failed to reset password")
+	ErrChangePassword       = errors.New("failed to change password")
+	ErrSamePassword         = errors.New("new password must differ from the current one")
+	ErrPasswordNotSet       = errors.New("account has no password yet, set one first")
+)
+
+type (
+	ForgotPasswordRequest struct {
+		Identifier string `json:"identifier" form:"identifier" binding:"required"`
+	}
+
+	ResetPasswordRequest struct {
+		Token    string `json:"token" form:"token" binding:"required"`
+		Password string `json:"password" form:"password" binding:"required"`
+	}
+
+	ChangePasswordRequest struct {
+		CurrentPassword string `json:"current_password" form:"current_password"`
+		NewPassword     string `json:"new_password" form:"new_password" binding:"required"`
+	}
+)
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_PERSONAL_ACCESS_TOKEN = "failed create personal access token"
+	MESSAGE_FAILED_GET_PERSONAL_ACCESS_TOKENS   = "failed get personal access tokens"
+	MESSAGE_FAILED_REVOKE_PERSONAL_ACCESS_TOKEN = "failed revoke personal access token"
+	MESSAGE_FAILED_INSUFFICIENT_SCOPE           = "token is missing the required scope"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_PERSONAL_ACCESS_TOKEN = "success create personal access token"
+	MESSAGE_SUCCESS_GET_PERSONAL_ACCESS_TOKENS   = "success get personal access tokens"
+	MESSAGE_SUCCESS_REVOKE_PERSONAL_ACCESS_TOKEN = "success revoke personal access token"
+)
+
+var (
+	ErrInvalidScope                = errors.New("invalid scope")
+	ErrPersonalAccessTokenLimit    = errors.New("too many personal access tokens, revoke one first")
+	ErrCreatePersonalAccessToken   = errors.New("failed to create personal access token")
+	ErrGetPersonalAccessTokens     = errors.New("failed to get personal access tokens")
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrRevokePersonalAccessToken   = errors.New("failed to revoke personal access token")
+	ErrInvalidPersonalAccessToken  = errors.New("personal access token invalid, expired or revoked")
+)
+
+type (
+	PersonalAccessTokenCreateRequest struct {
+		Name          string   `json:"name" form:"name" binding:"required,max=100"`
+		Scopes        []string `json:"scopes" form:"scopes" binding:"required,min=1"`
+		ExpiresInDays int      `json:"expires_in_days" form:"expires_in_days" binding:"omitempty,min=1,max=365"`
+	}
+
+	PersonalAccessTokenResponse struct {
+		ID         string     `json:"id"`
+		Name       string     `json:"name"`
+		Hint       string     `json:"hint"`
+		Scopes     []string   `json:"scopes"`
+		LastUsedAt *time.Time `json:"last_used_at"`
+		ExpiresAt  *time.Time `json:"expires_at"`
+		CreatedAt  time.Time  `json:"created_at"`
+	}
+
+	// PersonalAccessTokenCreateResponse is the only time the token itself is
+	// returned, just its hash is stored.
+	PersonalAccessTokenCreateResponse struct {
+		PersonalAccessTokenResponse
+		Token string `json:"token"`
+	}
+)
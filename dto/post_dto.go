@@ -2,6 +2,8 @@ package dto
 
 import (
 	"errors"
+	"mime/multipart"
+	"time"
 
 	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 )
@@ -12,38 +14,124 @@ const (
 	MESSAGE_FAILED_CREATE_POST             = "failed create post"
 	MESSAGE_FAILED_GET_POST_ID             = "failed get post id"
 	MESSAGE_FAILED_UPDATE_POST             = "failed update post"
+	MESSAGE_FAILED_DELETE_POST             = "failed delete post"
 	MESSAGE_FAILED_GET_ALL_POSTS           = "failed get all posts"
+	MESSAGE_FAILED_GET_TIMELINE            = "failed get timeline"
+	MESSAGE_FAILED_REPOST_POST             = "failed repost post"
+	MESSAGE_FAILED_UNREPOST_POST           = "failed unrepost post"
+	MESSAGE_FAILED_GET_POST_HISTORY        = "failed get post history"
+	MESSAGE_FAILED_VOTE_POLL               = "failed vote poll"
 
 	// Succcess
-	MESSAGE_SUCCESS_CREATE_POST    = "success create post"
-	MESSAGE_SUCCESS_GET_POST_BY_ID = "success get post by id"
-	MESSAGE_SUCCESS_DELETE_POST    = "success delete post"
-	MESSAGE_SUCCESS_UPDATE_POST    = "success update post"
-	MESSAGE_SUCCESS_GET_ALL_POSTS  = "success get all posts"
+	MESSAGE_SUCCESS_CREATE_POST      = "success create post"
+	MESSAGE_SUCCESS_GET_POST_BY_ID   = "success get post by id"
+	MESSAGE_SUCCESS_DELETE_POST      = "success delete post"
+	MESSAGE_SUCCESS_UPDATE_POST      = "success update post"
+	MESSAGE_SUCCESS_GET_ALL_POSTS    = "success get all posts"
+	MESSAGE_SUCCESS_GET_TIMELINE     = "success get timeline"
+	MESSAGE_SUCCESS_REPOST_POST      = "success repost post"
+	MESSAGE_SUCCESS_UNREPOST_POST    = "success unrepost post"
+	MESSAGE_SUCCESS_GET_POST_HISTORY = "success get post history"
+	MESSAGE_SUCCESS_VOTE_POLL        = "success vote poll"
 )
 
 var (
-	ErrCreatePost     = errors.New("failed to create post")
-	ErrGetPostById    = errors.New("post not found")
-	ErrGetPostReplies = errors.New("failed to get post replies")
-	ErrParseParentID  = errors.New("failed to parse parent id")
-	ErrDeletePostById = errors.New("failed to delete post")
-	ErrUpdatePostById = errors.New("failed to update post")
+	ErrCreatePost        = errors.New("failed to create post")
+	ErrGetPostById       = errors.New("post not found")
+	ErrGetPostReplies    = errors.New("failed to get post replies")
+	ErrParseParentID     = errors.New("failed to parse parent id")
+	ErrGetQuotedPost     = errors.New("quoted post not found")
+	ErrDeletePostById    = errors.New("failed to delete post")
+	ErrUpdatePostById    = errors.New("failed to update post")
+	ErrGetTimeline       = errors.New("failed to get timeline")
+	ErrRepostPostById    = errors.New("failed to repost post")
+	ErrAlreadyReposted   = errors.New("post already reposted")
+	ErrCheckRepostedPost = errors.New("failed to check reposted post")
+	ErrUnrepostPostById  = errors.New("failed to unrepost post")
+	ErrEditWindowClosed  = errors.New("post can no longer be edited")
+	ErrEditLimitReached  = errors.New("post reached the maximum number of edits")
+	ErrEditConflict      = errors.New("post was edited at the same time, try again")
+	ErrGetPostHistory    = errors.New("failed to get post history")
+	ErrEmptyPost         = errors.New("post needs text, media or a poll")
+	ErrTooManyMedia      = errors.New("a post can have up to 4 images or a single GIF or video")
+	ErrUnsupportedMedia  = errors.New("media must be a JPEG, PNG, WebP or GIF image or an MP4 video")
+	ErrMediaTooLarge     = errors.New("media file is too large")
+	ErrAltTextTooLong    = errors.New("alt text is too long")
+	ErrTooManyAltTexts   = errors.New("there can't be more alt texts than media files")
+	ErrUploadMedia       = errors.New("failed to upload media")
+	ErrPollOptions       = errors.New("a poll needs 2 to 4 options")
+	ErrPollOptionText    = errors.New("poll options must be between 1 and 25 characters")
+	ErrPollDuration      = errors.New("a poll must run between 5 minutes and 7 days")
+	ErrPollWithMedia     = errors.New("a post can't have both media and a poll")
+	ErrPollNotFound      = errors.New("poll not found")
+	ErrPollClosed        = errors.New("poll is closed")
+	ErrPollOptionInvalid = errors.New("option is not part of the poll")
+	ErrAlreadyVoted      = errors.New("already voted in this poll")
+	ErrVotePoll          = errors.New("failed to vote poll")
 )
 
 type (
 	PostCreateRequest struct {
-		Text     string  `json:"text" form:"text" binding:"required"`
-		ParentID *uint64 `json:"parent_id," form:"parent_id"`
+		Text         string                  `json:"text" form:"text"`
+		ParentID     *uint64                 `json:"parent_id," form:"parent_id"`
+		QuotedPostID *uint64                 `json:"quoted_post_id" form:"quoted_post_id"`
+		Media        []*multipart.FileHeader `json:"media" form:"media"`
+		AltText      []string                `json:"alt_text" form:"alt_text"`
+
+		// PollOptions attaches a poll closing PollDurationMinutes from now
+		PollOptions         []string `json:"poll_options" form:"poll_options"`
+		PollDurationMinutes int      `json:"poll_duration_minutes" form:"poll_duration_minutes"`
+	}
+
+	// PollResponse only carries vote counts once the viewer voted or the poll
+	// closed, so earlier votes don't sway the viewer's own
+	PollResponse struct {
+		ID            string               `json:"id"`
+		Options       []PollOptionResponse `json:"options"`
+		ClosesAt      time.Time            `json:"closes_at"`
+		IsClosed      bool                 `json:"is_closed"`
+		TotalVotes    *uint64              `json:"total_votes"`
+		VotedOptionID *string              `json:"voted_option_id"`
+	}
+
+	PollOptionResponse struct {
+		ID    string  `json:"id"`
+		Text  string  `json:"text"`
+		Votes *uint64 `json:"votes"`
+	}
+
+	PollVoteRequest struct {
+		OptionID string `json:"option_id" form:"option_id" binding:"required"`
+	}
+
+	AttachmentResponse struct {
+		ID       string  `json:"id"`
+		Type     string  `json:"type"`
+		MimeType string  `json:"mime_type"`
+		URL      string  `json:"url"`
+		Width    int     `json:"width"`
+		Height   int     `json:"height"`
+		AltText  *string `json:"alt_text"`
 	}
 
 	PostResponse struct {
-		ID         uint64       `json:"id"`
-		Text       string       `json:"text"`
-		TotalLikes uint64       `json:"total_likes"`
-		ParentID   *uint64      `json:"parent_id"`
-		IsDeleted  bool         `json:"is_deleted"`
-		User       UserResponse `json:"user"`
+		ID           uint64               `json:"id"`
+		Text         string               `json:"text"`
+		TotalLikes   uint64               `json:"total_likes"`
+		TotalReposts uint64               `json:"total_reposts"`
+		ParentID     *uint64              `json:"parent_id"`
+		IsDeleted    bool                 `json:"is_deleted"`
+		IsHidden     bool                 `json:"is_hidden,omitempty"`
+		IsEdited     bool                 `json:"is_edited"`
+		EditedAt     *time.Time           `json:"edited_at,omitempty"`
+		User         UserResponse         `json:"user"`
+		RepostedBy   *UserResponse        `json:"reposted_by,omitempty"`
+		QuotedPostID *uint64              `json:"quoted_post_id,omitempty"`
+		QuotedPost   *PostResponse        `json:"quoted_post,omitempty"`
+		IsBookmarked bool                 `json:"is_bookmarked"`
+		Mentions     []MentionResponse    `json:"mentions"`
+		Attachments  []AttachmentResponse `json:"attachments"`
+		Poll         *PollResponse        `json:"poll,omitempty"`
 	}
 
 	PostWithRepliesResponse struct {
@@ -55,6 +143,19 @@ type (
 		Text string `json:"text" form:"text" binding:"required"`
 	}
 
+	PostRevisionResponse struct {
+		Version   int       `json:"version"`
+		Text      string    `json:"text"`
+		WrittenAt time.Time `json:"written_at"`
+	}
+
+	// PostHistoryResponse lists every version of a post, the current one first
+	PostHistoryResponse struct {
+		PostID    uint64                 `json:"post_id"`
+		EditCount int                    `json:"edit_count"`
+		Revisions []PostRevisionResponse `json:"revisions"`
+	}
+
 	PostPaginationResponse struct {
 		Data []PostResponse `json:"data"`
 		PaginationResponse
@@ -65,11 +166,31 @@ type (
 		PaginationResponse
 	}
 
+	TimelinePaginationRequest struct {
+		PaginationRequest
+		Cursor uint64 `form:"cursor"`
+	}
+
+	TimelineMetaResponse struct {
+		PaginationResponse
+		Cursor uint64 `json:"cursor"`
+	}
+
+	TimelinePaginationResponse struct {
+		Data []PostResponse `json:"data"`
+		TimelineMetaResponse
+	}
+
 	GetAllPostsRepositoryResponse struct {
 		Posts []entity.Post `json:"posts"`
 		PaginationResponse
 	}
 
+	GetTimelineRepositoryResponse struct {
+		GetAllPostsRepositoryResponse
+		Cursor uint64 `json:"cursor"`
+	}
+
 	GetAllRepliesRepositoryResponse struct {
 		Replies []entity.Post `json:"replies"`
 		PaginationResponse
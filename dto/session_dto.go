@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_REFRESH_TOKEN  = "failed refresh token"
+	MESSAGE_FAILED_LOGOUT         = "failed logout"
+	MESSAGE_FAILED_GET_SESSIONS   = "failed get sessions"
+	MESSAGE_FAILED_REVOKE_SESSION = "failed revoke session"
+
+	// Success
+	MESSAGE_SUCCESS_REFRESH_TOKEN  = "success refresh token"
+	MESSAGE_SUCCESS_LOGOUT         = "success logout"
+	MESSAGE_SUCCESS_GET_SESSIONS   = "success get sessions"
+	MESSAGE_SUCCESS_REVOKE_SESSION = "success revoke session"
+)
+
+var (
+	ErrCreateSession       = errors.New("failed to create session")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused, session revoked")
+	ErrSessionRevoked      = errors.New("session revoked or expired")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrRevokeSession       = errors.New("failed to revoke session")
+	ErrGetSessions         = errors.New("failed to get sessions")
+)
+
+type (
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
+	}
+
+	// SessionMetadata describes the device a session was created from
+	SessionMetadata struct {
+		UserAgent string
+		IPAddress string
+	}
+
+	SessionResponse struct {
+		ID         string    `json:"id"`
+		UserAgent  string    `json:"user_agent"`
+		IPAddress  string    `json:"ip_address"`
+		LastUsedAt time.Time `json:"last_used_at"`
+		ExpiresAt  time.Time `json:"expires_at"`
+		CreatedAt  time.Time `json:"created_at"`
+		IsCurrent  bool      `json:"is_current"`
+	}
+)
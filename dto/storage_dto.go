@@ -0,0 +1,6 @@
+package dto
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_FILE = "failed get file"
+)
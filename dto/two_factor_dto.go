@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_TWO_FACTOR_DATA_FROM_BODY = "failed get data from body"
+	MESSAGE_FAILED_SETUP_TWO_FACTOR              = "failed setup two factor authentication"
+	MESSAGE_FAILED_CONFIRM_TWO_FACTOR            = "failed confirm two factor authentication"
+	MESSAGE_FAILED_DISABLE_TWO_FACTOR            = "failed disable two factor authentication"
+
+	// Success
+	MESSAGE_SUCCESS_SETUP_TWO_FACTOR   = "success setup two factor authentication"
+	MESSAGE_SUCCESS_CONFIRM_TWO_FACTOR = "success confirm two factor authentication"
+	MESSAGE_SUCCESS_DISABLE_TWO_FACTOR = "success disable two factor authentication"
+	MESSAGE_SUCCESS_TWO_FACTOR_LOGIN   = "two factor code required"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two factor authentication already enabled")
+	ErrTwoFactorNotSetup       = errors.New("two factor authentication not set up")
+	ErrTwoFactorNotEnabled     = errors.New("two factor authentication not enabled")
+	ErrSetupTwoFactor          = errors.New("failed to setup two factor authentication")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two factor code")
+	ErrInvalidChallenge        = errors.New("login challenge invalid or expired")
+	ErrIssueChallenge          = errors.New("failed to issue login challenge")
+)
+
+type (
+	TwoFactorSetupResponse struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioning_uri"`
+	}
+
+	TwoFactorCodeRequest struct {
+		Code string `json:"code" form:"code" binding:"required"`
+	}
+
+	TwoFactorDisableRequest struct {
+		Password string `json:"password" form:"password" binding:"required"`
+		Code     string `json:"code" form:"code" binding:"required"`
+	}
+
+	// TwoFactorLoginRequest answers the challenge from login once, the code
+	// is either from the authenticator app or a recovery code
+	TwoFactorLoginRequest struct {
+		ChallengeToken string `json:"challenge_token" form:"challenge_token" binding:"required"`
+		Code           string `json:"code" form:"code" binding:"required"`
+	}
+
+	RecoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+)
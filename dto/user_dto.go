@@ -3,6 +3,9 @@ package dto
 import (
 	"errors"
 	"mime/multipart"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 )
 
 const (
@@ -15,17 +18,23 @@ const (
 	MESSAGE_FAILED_LOGIN                   = "failed login"
 	MESSAGE_FAILED_PROSES_REQUEST          = "failed proses request"
 	MESSAGE_FAILED_DENIED_ACCESS           = "denied access"
+	MESSAGE_FAILED_TOO_MANY_REQUESTS       = "too many requests"
+	MESSAGE_FAILED_REQUEST_TOO_LARGE       = "request body too large"
 	MESSAGE_FAILED_UPDATE_USER             = "failed update user"
 	MESSAGE_FAILED_USERNAME_EXISTS         = "failed get username"
 	MESSAGE_FAILED_GET_USER_POSTS          = "failed get user posts"
+	MESSAGE_FAILED_VERIFY_EMAIL            = "failed verify email"
+	MESSAGE_FAILED_RESEND_VERIFICATION     = "failed resend verification email"
 
 	// Success
-	MESSAGE_SUCCESS_REGISTER_USER      = "success create user"
-	MESSAGE_SUCCESS_GET_USER           = "success get user"
-	MESSAGE_SUCCESS_LOGIN              = "success login"
-	MESSAGE_SUCCESS_UPDATE_USER        = "success update user"
-	MESSAGE_SUCCESS_USERNAME_AVAILABLE = "username available"
-	MESSAGE_SUCCESS_GET_USER_POSTS     = "success get user posts"
+	MESSAGE_SUCCESS_REGISTER_USER       = "success create user"
+	MESSAGE_SUCCESS_GET_USER            = "success get user"
+	MESSAGE_SUCCESS_LOGIN               = "success login"
+	MESSAGE_SUCCESS_UPDATE_USER         = "success update user"
+	MESSAGE_SUCCESS_USERNAME_AVAILABLE  = "username available"
+	MESSAGE_SUCCESS_GET_USER_POSTS      = "success get user posts"
+	MESSAGE_SUCCESS_VERIFY_EMAIL        = "success verify email"
+	MESSAGE_SUCCESS_RESEND_VERIFICATION = "success resend verification email"
 )
 
 var (
@@ -35,27 +44,52 @@ var (
 	ErrUsernameNotFound      = errors.New("username not found")
 	ErrPasswordNotMatch      = errors.New("password not match")
 	ErrUnauthorized          = errors.New("unauthorized")
+	ErrUserSuspended         = errors.New("account suspended")
+	ErrAccountLocked         = errors.New("too many failed login attempts, try again later")
+	ErrEmailAlreadyExists    = errors.New("email already exist")
+	ErrEmailNotVerified      = errors.New("email not verified")
+	ErrEmailAlreadyVerified  = errors.New("email already verified")
+	ErrInvalidVerification   = errors.New("verification link invalid or expired")
+	ErrVerifyEmail           = errors.New("failed to verify email")
+	ErrVerificationThrottled = errors.New("verification email sent recently, try again later")
+	ErrSendVerification      = errors.New("failed to send verification email")
+	ErrInvalidImage          = errors.New("image must be a JPEG, PNG, GIF or WebP picture")
+	ErrImageDimensions       = errors.New("image has too many pixels")
 )
 
 type (
 	UserCreateRequest struct {
 		Name     string `json:"name" form:"name" binding:"required"`
 		UserName string `json:"username" form:"username" binding:"required"`
+		Email    string `json:"email" form:"email" binding:"required,email"`
 		Password string `json:"password" form:"password" binding:"required"`
 	}
 
+	VerifyEmailRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+
 	UserProfileUpdateRequest struct {
-		Name  string                `json:"name" form:"name"`
-		Bio   string                `json:"bio" form:"bio"`
-		Image *multipart.FileHeader `json:"image" form:"image"`
+		Name   string                `json:"name" form:"name"`
+		Bio    string                `json:"bio" form:"bio"`
+		Image  *multipart.FileHeader `json:"image" form:"image"`
+		Banner *multipart.FileHeader `json:"banner" form:"banner"`
 	}
 
 	UserResponse struct {
-		ID       string  `json:"id"`
-		Name     string  `json:"name"`
-		UserName string  `json:"username"`
-		Bio      *string `json:"bio"`
-		ImageUrl *string `json:"image_url"`
+		ID             string  `json:"id"`
+		Name           string  `json:"name"`
+		UserName       string  `json:"username"`
+		Bio            *string `json:"bio"`
+		ImageUrl       *string `json:"image_url"`
+		TotalFollowers uint64  `json:"total_followers"`
+		TotalFollowing uint64  `json:"total_following"`
+		IsVerified     bool    `json:"is_verified"`
+
+		// ImageUrls and BannerUrls link every generated size by its width in
+		// pixels, ImageUrl is the largest profile image size
+		ImageUrls  map[string]string `json:"image_urls"`
+		BannerUrls map[string]string `json:"banner_urls"`
 	}
 
 	UserLoginRequest struct {
@@ -64,7 +98,15 @@ type (
 	}
 
 	UserLoginResponse struct {
-		Token string `json:"token"`
+		Token        string    `json:"token"`
+		ExpiresAt    time.Time `json:"expires_at"`
+		RefreshToken string    `json:"refresh_token"`
+		SessionID    string    `json:"session_id"`
+
+		// set instead of the tokens when the account has two factor
+		// authentication, ExpiresAt is then when the challenge expires
+		TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+		ChallengeToken    string `json:"challenge_token,omitempty"`
 	}
 
 	CheckUsernameRequest struct {
@@ -75,4 +117,14 @@ type (
 		PaginationRequest
 		IsLiked bool `form:"is_liked"`
 	}
+
+	UserPaginationResponse struct {
+		Data []UserResponse `json:"data"`
+		PaginationResponse
+	}
+
+	GetAllUsersRepositoryResponse struct {
+		Users []entity.User `json:"users"`
+		PaginationResponse
+	}
 )
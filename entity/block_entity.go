@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Block struct {
+	BlockerID uuid.UUID `gorm:"primaryKey;not null" json:"blocker_id"`
+	Blocker   User      `gorm:"foreignkey:BlockerID" json:"blocker"`
+
+	BlockedID uuid.UUID `gorm:"primaryKey;not null;index" json:"blocked_id"`
+	Blocked   User      `gorm:"foreignkey:BlockedID" json:"blocked"`
+
+	Timestamp
+}
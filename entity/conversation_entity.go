@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Conversation struct {
+	ID      uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name    *string `json:"name"`
+	IsGroup bool    `gorm:"default:false" json:"is_group"`
+
+	CreatedByID uuid.UUID `gorm:"not null" json:"created_by_id"`
+	CreatedBy   User      `gorm:"foreignkey:CreatedByID" json:"created_by"`
+
+	LastMessage   *Message  `gorm:"foreignkey:LastMessageID" json:"last_message,omitempty"`
+	LastMessageID *uint64   `json:"last_message_id,omitempty"`
+	LastMessageAt time.Time `gorm:"index" json:"last_message_at"`
+
+	Participants []ConversationParticipant `gorm:"foreignkey:ConversationID" json:"participants,omitempty"`
+
+	Timestamp
+}
+
+// ConversationParticipant keeps the read receipt of a user in a conversation
+type ConversationParticipant struct {
+	ConversationID uint64    `gorm:"primaryKey;not null" json:"conversation_id"`
+	UserID         uuid.UUID `gorm:"primaryKey;not null;index" json:"user_id"`
+	User           User      `gorm:"foreignkey:UserID" json:"user"`
+
+	LastReadMessageID *uint64    `json:"last_read_message_id,omitempty"`
+	LastReadAt        *time.Time `json:"last_read_at,omitempty"`
+
+	Timestamp
+}
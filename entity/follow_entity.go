@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Follow struct {
+	FollowerID uuid.UUID `gorm:"primaryKey;not null" json:"follower_id"`
+	Follower   User      `gorm:"foreignkey:FollowerID" json:"follower"`
+
+	FollowingID uuid.UUID `gorm:"primaryKey;not null" json:"following_id"`
+	Following   User      `gorm:"foreignkey:FollowingID" json:"following"`
+
+	Timestamp
+}
@@ -0,0 +1,18 @@
+package entity
+
+type Hashtag struct {
+	ID   uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name string `gorm:"uniqueIndex;not null" json:"name"`
+
+	Timestamp
+}
+
+type PostHashtag struct {
+	PostID uint64 `gorm:"primaryKey;not null" json:"post_id"`
+	Post   Post   `gorm:"foreignkey:PostID" json:"post"`
+
+	HashtagID uint64  `gorm:"primaryKey;not null" json:"hashtag_id"`
+	Hashtag   Hashtag `gorm:"foreignkey:HashtagID" json:"hashtag"`
+
+	Timestamp
+}
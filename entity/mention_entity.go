@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Mention struct {
+	PostID     uint64 `gorm:"primaryKey;not null" json:"post_id"`
+	StartIndex int    `gorm:"primaryKey;not null" json:"start_index"`
+	EndIndex   int    `gorm:"not null" json:"end_index"`
+
+	UserID uuid.UUID `gorm:"index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Message struct {
+	ID             uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	ConversationID uint64 `gorm:"index;not null" json:"conversation_id"`
+	Text           string `gorm:"not null" json:"text"`
+
+	SenderID uuid.UUID `gorm:"not null" json:"sender_id"`
+	Sender   User      `gorm:"foreignkey:SenderID" json:"sender"`
+
+	Timestamp
+}
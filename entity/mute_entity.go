@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Mute struct {
+	MuterID uuid.UUID `gorm:"primaryKey;not null" json:"muter_id"`
+	Muter   User      `gorm:"foreignkey:MuterID" json:"muter"`
+
+	MutedID uuid.UUID `gorm:"primaryKey;not null" json:"muted_id"`
+	Muted   User      `gorm:"foreignkey:MutedID" json:"muted"`
+
+	Timestamp
+}
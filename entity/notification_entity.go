@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a single event, events sharing a GroupKey are shown to the
+// recipient as one entry ("X and 4 others liked your post").
+type Notification struct {
+	ID       uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	Type     string `gorm:"not null" json:"type"`
+	GroupKey string `gorm:"index;not null" json:"group_key"`
+
+	RecipientID uuid.UUID `gorm:"index;not null" json:"recipient_id"`
+	Recipient   User      `gorm:"foreignkey:RecipientID" json:"recipient"`
+
+	ActorID uuid.UUID `gorm:"not null" json:"actor_id"`
+	Actor   User      `gorm:"foreignkey:ActorID" json:"actor"`
+
+	PostID *uint64 `json:"post_id,omitempty"`
+	Post   *Post   `gorm:"foreignkey:PostID" json:"post,omitempty"`
+
+	ReadAt *time.Time `json:"read_at,omitempty"`
+
+	Timestamp
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OIDCState is a pending social login, it keeps the PKCE verifier and nonce
+// on the server until the provider sends the user back with the state. A
+// state started to link an account only finishes a link by the same user.
+type OIDCState struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	StateHash    string     `gorm:"uniqueIndex;not null" json:"-"`
+	Provider     string     `gorm:"not null" json:"provider"`
+	Purpose      string     `gorm:"not null;default:login" json:"purpose"`
+	UserID       *uuid.UUID `gorm:"type:uuid" json:"user_id,omitempty"`
+	CodeVerifier string     `gorm:"not null" json:"-"`
+	Nonce        string     `gorm:"not null" json:"-"`
+	ExpiresAt    time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt       *time.Time `json:"used_at,omitempty"`
+
+	Timestamp
+}
+
+// UserIdentity links an account at an OpenID Connect provider to a user, the
+// subject is the provider's stable id for that account.
+type UserIdentity struct {
+	ID       uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	Provider string  `gorm:"not null;uniqueIndex:idx_user_identities_provider_subject" json:"provider"`
+	Subject  string  `gorm:"not null;uniqueIndex:idx_user_identities_provider_subject" json:"subject"`
+	Email    *string `json:"email"`
+
+	UserID uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
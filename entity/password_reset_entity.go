@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset is a single use reset token, only its hash is stored
+type PasswordReset struct {
+	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+
+	UserID uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
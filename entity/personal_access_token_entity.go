@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalAccessToken lets bots act for a user without their password, it
+// only reaches routes that ask for one of its space separated scopes.
+type PersonalAccessToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	Name       string     `gorm:"not null" json:"name"`
+	TokenHash  string     `gorm:"uniqueIndex;not null" json:"-"`
+	Hint       string     `gorm:"not null" json:"hint"`
+	Scopes     string     `gorm:"not null" json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	UserID uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
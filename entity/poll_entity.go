@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poll lets readers pick one of its options until ClosesAt. The vote totals
+// are kept on the poll and its options so they don't have to be counted.
+type Poll struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	PostID     uint64       `gorm:"uniqueIndex;not null" json:"post_id"`
+	ClosesAt   time.Time    `gorm:"type:timestamp with time zone;not null" json:"closes_at"`
+	TotalVotes uint64       `gorm:"not null;default:0" json:"total_votes"`
+	Options    []PollOption `gorm:"foreignkey:PollID" json:"options,omitempty"`
+
+	Timestamp
+}
+
+type PollOption struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	PollID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_poll_options_poll_position" json:"poll_id"`
+	Position   int       `gorm:"not null;uniqueIndex:idx_poll_options_poll_position" json:"position"`
+	Text       string    `gorm:"not null" json:"text"`
+	TotalVotes uint64    `gorm:"not null;default:0" json:"total_votes"`
+
+	Timestamp
+}
+
+// PollVote is keyed by poll and user, a user can only vote once per poll
+type PollVote struct {
+	PollID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"poll_id"`
+	UserID   uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	OptionID uuid.UUID `gorm:"type:uuid;not null" json:"option_id"`
+
+	Timestamp
+}
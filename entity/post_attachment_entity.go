@@ -0,0 +1,20 @@
+package entity
+
+import "github.com/google/uuid"
+
+// PostAttachment is an image, GIF or video shown with a post, in the order
+// given by Position.
+type PostAttachment struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	PostID   uint64    `gorm:"index;not null" json:"post_id"`
+	Position int       `gorm:"not null" json:"position"`
+	Type     string    `gorm:"not null" json:"type"`
+	MimeType string    `gorm:"not null" json:"mime_type"`
+	Path     string    `gorm:"not null" json:"path"`
+	Width    int       `gorm:"not null" json:"width"`
+	Height   int       `gorm:"not null" json:"height"`
+	Size     int64     `gorm:"not null" json:"size"`
+	AltText  *string   `json:"alt_text"`
+
+	Timestamp
+}
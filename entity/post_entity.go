@@ -1,17 +1,34 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Post struct {
-	ID         uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
-	Text       string `gorm:"not null" json:"text"`
-	TotalLikes uint64 `gorm:"default:0" json:"total_likes"`
+	ID           uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Text         string     `gorm:"not null" json:"text"`
+	TotalLikes   uint64     `gorm:"default:0" json:"total_likes"`
+	TotalReposts uint64     `gorm:"default:0" json:"total_reposts"`
+	EditCount    int        `gorm:"default:0" json:"edit_count"`
+	EditedAt     *time.Time `gorm:"type:timestamp with time zone" json:"edited_at,omitempty"`
 
 	Parent   *Post   `gorm:"foreignkey:ParentID" json:"parent,omitempty"`
 	ParentID *uint64 `json:"parent_id,omitempty"`
 
+	RepostOf   *Post   `gorm:"foreignkey:RepostOfID" json:"repost_of,omitempty"`
+	RepostOfID *uint64 `gorm:"index" json:"repost_of_id,omitempty"`
+
+	QuotedPost   *Post   `gorm:"foreignkey:QuotedPostID" json:"quoted_post,omitempty"`
+	QuotedPostID *uint64 `gorm:"index" json:"quoted_post_id,omitempty"`
+
 	UserID uuid.UUID `gorm:"not null" json:"user_id"`
 	User   User      `gorm:"foreignkey:UserID" json:"user"`
 
+	Mentions    []Mention        `gorm:"foreignkey:PostID" json:"mentions,omitempty"`
+	Attachments []PostAttachment `gorm:"foreignkey:PostID" json:"attachments,omitempty"`
+	Poll        *Poll            `gorm:"foreignkey:PostID" json:"poll,omitempty"`
+
 	Timestamp
 }
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// PostRevision keeps the text a post had before an edit replaced it, Version
+// 0 being the text the post was created with.
+type PostRevision struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	PostID    uint64    `gorm:"not null;uniqueIndex:idx_post_revisions_post_version" json:"post_id"`
+	Version   int       `gorm:"not null;uniqueIndex:idx_post_revisions_post_version" json:"version"`
+	Text      string    `gorm:"not null" json:"text"`
+	WrittenAt time.Time `gorm:"type:timestamp with time zone;not null" json:"written_at"`
+
+	Timestamp
+}
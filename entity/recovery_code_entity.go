@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode signs in once when the authenticator app is lost, only its
+// hash is stored
+type RecoveryCode struct {
+	ID       uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CodeHash string     `gorm:"index;not null" json:"-"`
+	UsedAt   *time.Time `json:"used_at,omitempty"`
+
+	UserID uuid.UUID `gorm:"type:uuid;index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a signed-in device, access tokens carry its id so revoking the
+// session logs the device out.
+type Session struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	LastUsedAt time.Time  `json:"last_used_at"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+
+	UserID uuid.UUID `gorm:"index;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	Timestamp
+}
+
+// RefreshToken is single use, each refresh marks it used and issues the next
+// one for the same session.
+type RefreshToken struct {
+	ID        uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+
+	SessionID uuid.UUID `gorm:"type:uuid;index;not null" json:"session_id"`
+	Session   Session   `gorm:"foreignkey:SessionID" json:"session"`
+
+	Timestamp
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Timeline struct {
+	UserID uuid.UUID `gorm:"primaryKey;not null" json:"user_id"`
+	User   User      `gorm:"foreignkey:UserID" json:"user"`
+
+	PostID uint64 `gorm:"primaryKey;not null" json:"post_id"`
+	Post   Post   `gorm:"foreignkey:PostID" json:"post"`
+
+	AuthorID uuid.UUID `gorm:"index;not null" json:"author_id"`
+
+	Timestamp
+}
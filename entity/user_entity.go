@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,9 +14,42 @@ type User struct {
 	Username string    `gorm:"not null" gorm:"unique" json:"username"`
 	Bio      *string   `json:"bio"`
 	Password string    `gorm:"not null" json:"password"`
-	ImageUrl *string   `json:"image_url"`
+	// PasswordUnset marks accounts registered through an identity provider,
+	// their random password is unknown until the user sets one
+	PasswordUnset bool    `gorm:"not null;default:false" json:"-"`
+	Email         *string `gorm:"uniqueIndex" json:"email"`
+	// ImageUrl and BannerUrl are the storage keys the sizes of the profile
+	// image and banner are kept below
+	ImageUrl  *string `json:"image_url"`
+	BannerUrl *string `json:"banner_url"`
+
+	Role        string     `gorm:"not null;default:user" json:"role"`
+	SuspendedAt *time.Time `json:"suspended_at"`
+
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	VerificationSentAt *time.Time `json:"verification_sent_at"`
+
+	// TwoFactorSecret is AES encrypted, it is set on setup and only checked
+	// on login once TwoFactorEnabledAt is set by confirming a code
+	TwoFactorSecret    *string    `json:"-"`
+	TwoFactorEnabledAt *time.Time `json:"two_factor_enabled_at"`
+	TwoFactorLastStep  int64      `gorm:"not null;default:0" json:"-"`
+
+	// FailedLoginAttempts counts wrong passwords since the last successful
+	// login, past the lockout threshold each one locks the account for longer
+	FailedLoginAttempts int        `gorm:"not null;default:0" json:"-"`
+	LockedUntil         *time.Time `json:"-"`
+
+	// DeletionScheduledAt is when a deleted account gets purged, until then it
+	// can still be restored
+	DeletionScheduledAt *time.Time `gorm:"index" json:"deletion_scheduled_at"`
+
+	TotalFollowers uint64 `gorm:"default:0" json:"total_followers"`
+	TotalFollowing uint64 `gorm:"default:0" json:"total_following"`
 
-	Posts []Post `gorm:"foreignkey:UserID" json:"posts,omitempty"`
+	Posts     []Post   `gorm:"foreignkey:UserID" json:"posts,omitempty"`
+	Followers []Follow `gorm:"foreignkey:FollowingID" json:"followers,omitempty"`
+	Following []Follow `gorm:"foreignkey:FollowerID" json:"following,omitempty"`
 
 	Timestamp
 }
@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSignedTokenInvalid = errors.New("token invalid")
+	ErrSignedTokenExpired = errors.New("token expired")
+)
+
+// GenerateToken returns a random url-safe token, only its hash is stored
+func GenerateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignToken packs the payload and its expiry into a url-safe token signed
+// with HMAC-SHA256, so it can be checked without storing anything.
+func SignToken(secret string, payload string, expiresAt time.Time) string {
+	data := payload + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(data)) + "." + base64.RawURLEncoding.EncodeToString(signature(secret, data))
+}
+
+// VerifySignedToken returns the payload of a token made by SignToken
+func VerifySignedToken(secret string, token string) (string, error) {
+	encodedData, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", ErrSignedTokenInvalid
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return "", ErrSignedTokenInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(sig, signature(secret, string(data))) {
+		return "", ErrSignedTokenInvalid
+	}
+
+	separator := strings.LastIndex(string(data), "|")
+	if separator < 0 {
+		return "", ErrSignedTokenInvalid
+	}
+
+	expiresAt, err := strconv.ParseInt(string(data[separator+1:]), 10, 64)
+	if err != nil {
+		return "", ErrSignedTokenInvalid
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return "", ErrSignedTokenExpired
+	}
+
+	return string(data[:separator]), nil
+}
+
+func signature(secret string, data string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// PKCEChallenge derives the S256 code challenge sent along with the
+// authorization request from the verifier kept on the server.
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
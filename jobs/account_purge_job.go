@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+)
+
+type (
+	AccountPurgeJob struct {
+		accountService service.AccountService
+		interval       time.Duration
+	}
+)
+
+func NewAccountPurgeJob(accountService service.AccountService, interval time.Duration) *AccountPurgeJob {
+	return &AccountPurgeJob{
+		accountService: accountService,
+		interval:       interval,
+	}
+}
+
+// Run purges accounts past their deletion grace period right away and then
+// on every interval, batches repeat until no account is left to purge.
+func (j *AccountPurgeJob) Run() {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.purge()
+		<-ticker.C
+	}
+}
+
+func (j *AccountPurgeJob) purge() {
+	for {
+		purged, err := j.accountService.PurgeDeletedAccounts(context.Background())
+		if purged > 0 {
+			log.Printf("purged %d deleted accounts", purged)
+		}
+
+		if err != nil {
+			log.Printf("error purging deleted accounts: %v", err)
+			return
+		}
+
+		if purged == 0 {
+			return
+		}
+	}
+}
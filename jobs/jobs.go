@@ -0,0 +1,16 @@
+package jobs
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+)
+
+// Start runs the background jobs of the server, each in its own goroutine
+func Start(injector *do.Injector) {
+	accountService := do.MustInvokeNamed[service.AccountService](injector, constants.AccountService)
+
+	accountPurgeJob := NewAccountPurgeJob(accountService, config.GetAccountConfig().PurgeInterval)
+	go accountPurgeJob.Run()
+}
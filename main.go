@@ -3,8 +3,10 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/Lab-RPL-ITS/twitter-clone-api/command"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/jobs"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/provider"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/routes"
@@ -24,8 +26,6 @@ func args(injector *do.Injector) bool {
 }
 
 func run(server *gin.Engine) {
-	server.Static("/assets", "./assets")
-
 	if os.Getenv("IS_LOGGER") == "true" {
 		routes.LoggerRoute(server)
 	}
@@ -64,8 +64,21 @@ func main() {
 	server := gin.Default()
 	server.Use(middleware.CORSMiddleware())
 
+	// rate limits key on the client ip, X-Forwarded-For is ignored unless it
+	// comes from one of the proxies in TRUSTED_PROXIES
+	var trustedProxies []string
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		trustedProxies = strings.Split(proxies, ",")
+	}
+	if err := server.SetTrustedProxies(trustedProxies); err != nil {
+		log.Fatalf("error setting trusted proxies: %v", err)
+	}
+
 	// routes
 	routes.RegisterRoutes(server, injector)
 
+	// background jobs
+	jobs.Start(injector)
+
 	run(server)
 }
@@ -2,15 +2,20 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/gin-gonic/gin"
 )
 
-func Authenticate(jwtService service.JWTService) gin.HandlerFunc {
+// Authenticate accepts access tokens and personal access tokens. The latter
+// are rejected unless the route lists scopes and the token was granted all
+// of them, so routes without scopes stay reserved for signed-in users.
+func Authenticate(jwtService service.JWTService, scopes ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.GetHeader("Authorization")
 
@@ -27,6 +32,11 @@ func Authenticate(jwtService service.JWTService) gin.HandlerFunc {
 		}
 
 		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
+		if strings.HasPrefix(authHeader, constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX) {
+			authenticatePersonalAccessToken(ctx, jwtService, authHeader, scopes)
+			return
+		}
+
 		token, err := jwtService.ValidateToken(authHeader)
 		if err != nil {
 			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
@@ -47,6 +57,83 @@ func Authenticate(jwtService service.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		sessionId, err := jwtService.GetSessionIDByToken(authHeader)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role, err := jwtService.GetRoleByToken(authHeader)
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		ctx.Set("token", authHeader)
+		ctx.Set("user_id", userId)
+		ctx.Set("session_id", sessionId)
+		ctx.Set("role", role)
+		ctx.Next()
+	}
+}
+
+func authenticatePersonalAccessToken(ctx *gin.Context, jwtService service.JWTService, token string, scopes []string) {
+	pat, err := jwtService.ValidatePersonalAccessToken(token)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOKEN_NOT_VALID, nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	granted := strings.Fields(pat.Scopes)
+	if len(scopes) == 0 || slices.ContainsFunc(scopes, func(scope string) bool { return !slices.Contains(granted, scope) }) {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_INSUFFICIENT_SCOPE, nil)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+		return
+	}
+
+	ctx.Set("token", token)
+	ctx.Set("user_id", pat.UserID.String())
+	ctx.Set("role", pat.User.Role)
+	ctx.Set("scopes", granted)
+	ctx.Next()
+}
+
+// OptionalAuthenticate sets the user id like Authenticate when a valid token
+// is sent, but lets anonymous requests through on public endpoints. Personal
+// access tokens need the read scope to count.
+func OptionalAuthenticate(jwtService service.JWTService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authHeader := ctx.GetHeader("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			ctx.Next()
+			return
+		}
+
+		authHeader = strings.TrimPrefix(authHeader, "Bearer ")
+		if strings.HasPrefix(authHeader, constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX) {
+			pat, err := jwtService.ValidatePersonalAccessToken(authHeader)
+			if err == nil && slices.Contains(strings.Fields(pat.Scopes), constants.ENUM_SCOPE_READ) {
+				ctx.Set("token", authHeader)
+				ctx.Set("user_id", pat.UserID.String())
+			}
+			ctx.Next()
+			return
+		}
+
+		if _, err := jwtService.ValidateToken(authHeader); err != nil {
+			ctx.Next()
+			return
+		}
+
+		userId, err := jwtService.GetUserIDByToken(authHeader)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
 		ctx.Set("token", authHeader)
 		ctx.Set("user_id", userId)
 		ctx.Next()
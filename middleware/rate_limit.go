@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitByIP limits requests per client ip. Routes limited under the same
+// name share one bucket.
+func RateLimitByIP(store utils.RateLimitStore, name string, rate config.Rate) gin.HandlerFunc {
+	return rateLimit(store, rate, func(ctx *gin.Context) string {
+		return "ratelimit:" + name + ":ip:" + ctx.ClientIP()
+	})
+}
+
+// RateLimitByUser limits requests per user, it has to run after Authenticate
+// and falls back to the client ip for anonymous requests.
+func RateLimitByUser(store utils.RateLimitStore, name string, rate config.Rate) gin.HandlerFunc {
+	return rateLimit(store, rate, func(ctx *gin.Context) string {
+		if userId := ctx.GetString("user_id"); userId != "" {
+			return "ratelimit:" + name + ":user:" + userId
+		}
+		return "ratelimit:" + name + ":ip:" + ctx.ClientIP()
+	})
+}
+
+// rateLimit sets the RateLimit-* headers of the IETF draft on every response
+// and Retry-After once the bucket is empty. Requests are let through when the
+// store fails, an outage there shouldn't take the api down with it.
+func rateLimit(store utils.RateLimitStore, rate config.Rate, key func(ctx *gin.Context) string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if rate.Limit <= 0 {
+			ctx.Next()
+			return
+		}
+
+		result, err := store.Take(ctx.Request.Context(), key(ctx), rate)
+		if err != nil {
+			log.Println(err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rate.Limit, int(rate.Period.Seconds())))
+		ctx.Header("RateLimit-Limit", strconv.Itoa(rate.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", seconds(result.ResetAfter))
+
+		if !result.Allowed {
+			ctx.Header("Retry-After", seconds(result.RetryAfter))
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_PROSES_REQUEST, dto.MESSAGE_FAILED_TOO_MANY_REQUESTS, nil)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, response)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -10,6 +10,31 @@ func Migrate(db *gorm.DB) error {
 		&entity.User{},
 		&entity.Post{},
 		&entity.Like{},
+		&entity.Follow{},
+		&entity.Timeline{},
+		&entity.Bookmark{},
+		&entity.Mention{},
+		&entity.Hashtag{},
+		&entity.PostHashtag{},
+		&entity.Notification{},
+		&entity.Conversation{},
+		&entity.ConversationParticipant{},
+		&entity.Message{},
+		&entity.Block{},
+		&entity.Mute{},
+		&entity.Session{},
+		&entity.RefreshToken{},
+		&entity.PasswordReset{},
+		&entity.RecoveryCode{},
+		&entity.TwoFactorChallenge{},
+		&entity.PersonalAccessToken{},
+		&entity.OIDCState{},
+		&entity.UserIdentity{},
+		&entity.PostRevision{},
+		&entity.PostAttachment{},
+		&entity.Poll{},
+		&entity.PollOption{},
+		&entity.PollVote{},
 	); err != nil {
 		return err
 	}
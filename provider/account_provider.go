@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideAccountDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+	sessionService := do.MustInvokeNamed[service.SessionService](injector, constants.SessionService)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	postAttachmentRepository := repository.NewPostAttachmentRepository(db)
+
+	// Service
+	accountService := service.NewAccountService(userRepository, postAttachmentRepository, sessionService, storage, config.GetAccountConfig())
+	do.ProvideNamed(injector, constants.AccountService, func(i *do.Injector) (service.AccountService, error) {
+		return accountService, nil
+	})
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.AccountController, error) {
+		return controller.NewAccountController(accountService), nil
+	})
+}
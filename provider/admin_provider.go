@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideAdminDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	postRepository := repository.NewPostRepository(db)
+	postAttachmentRepository := repository.NewPostAttachmentRepository(db)
+	sessionRepository := repository.NewSessionRepository(db)
+
+	// Service
+	adminService := service.NewAdminService(userRepository, postRepository, postAttachmentRepository, sessionRepository, storage)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.AdminController, error) {
+		return controller.NewAdminController(adminService), nil
+	})
+}
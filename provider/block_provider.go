@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideBlockDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+	muteRepository := repository.NewMuteRepository(db)
+	followRepository := repository.NewFollowRepository(db)
+	timelineRepository := repository.NewTimelineRepository(db, config.GetTimelineStrategy())
+
+	// Service
+	blockService := service.NewBlockService(userRepository, blockRepository, muteRepository, followRepository, timelineRepository, jwtService, storage)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.BlockController, error) {
+		return controller.NewBlockController(blockService), nil
+	})
+}
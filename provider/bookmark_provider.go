@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideBookmarkDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+
+	// Repository
+	bookmarkRepository := repository.NewBookmarkRepository(db)
+	postRepository := repository.NewPostRepository(db)
+	pollRepository := repository.NewPollRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+
+	// Service
+	bookmarkService := service.NewBookmarkService(bookmarkRepository, postRepository, pollRepository, blockRepository, jwtService, storage)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.BookmarkController, error) {
+		return controller.NewBookmarkController(bookmarkService), nil
+	})
+}
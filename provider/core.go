@@ -3,7 +3,9 @@ package provider
 import (
 	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
@@ -18,10 +20,46 @@ func RegisterDependencies(injector *do.Injector) {
 	InitDatabase(injector)
 
 	do.ProvideNamed(injector, constants.JWTService, func(i *do.Injector) (service.JWTService, error) {
-		return service.NewJWTService(), nil
+		db := do.MustInvokeNamed[*gorm.DB](i, constants.DB)
+		jwtConfig, err := config.GetJWTConfig()
+		if err != nil {
+			return nil, err
+		}
+		return service.NewJWTService(repository.NewSessionRepository(db), repository.NewPersonalAccessTokenRepository(db), jwtConfig, config.GetSessionConfig().AccessTokenTTL), nil
 	})
 
+	do.ProvideNamed(injector, constants.Mailer, func(i *do.Injector) (utils.Mailer, error) {
+		return utils.NewMailer(config.GetMailConfig()), nil
+	})
+
+	do.ProvideNamed(injector, constants.Storage, func(i *do.Injector) (utils.Storage, error) {
+		storageConfig, err := config.GetStorageConfig()
+		if err != nil {
+			return nil, err
+		}
+		return utils.NewStorage(storageConfig), nil
+	})
+
+	do.ProvideNamed(injector, constants.RateLimitStore, func(i *do.Injector) (utils.RateLimitStore, error) {
+		return utils.NewMemoryRateLimitStore(), nil
+	})
+
+	ProvideSessionDependencies(injector)
+	ProvideTwoFactorDependencies(injector)
+	ProvideNotificationDependencies(injector)
 	ProvideUserDependencies(injector)
 	ProvidePostDependencies(injector)
 	ProvideLikesDependencies(injector)
+	ProvideFollowDependencies(injector)
+	ProvideBookmarkDependencies(injector)
+	ProvideHashtagDependencies(injector)
+	ProvideConversationDependencies(injector)
+	ProvideBlockDependencies(injector)
+	ProvideAdminDependencies(injector)
+	ProvidePasswordDependencies(injector)
+	ProvideAccountDependencies(injector)
+	ProvideJWKSDependencies(injector)
+	ProvidePersonalAccessTokenDependencies(injector)
+	ProvideOIDCDependencies(injector)
+	ProvideStorageDependencies(injector)
 }
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideHashtagDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+
+	// Repository
+	hashtagRepository := repository.NewHashtagRepository(db)
+	bookmarkRepository := repository.NewBookmarkRepository(db)
+	pollRepository := repository.NewPollRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+
+	// Service
+	hashtagService := service.NewHashtagService(hashtagRepository, bookmarkRepository, pollRepository, blockRepository, config.GetTrendsConfig(), storage)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.HashtagController, error) {
+		return controller.NewHashtagController(hashtagService), nil
+	})
+}
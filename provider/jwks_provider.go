@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+)
+
+func ProvideJWKSDependencies(injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.JWKSController, error) {
+		return controller.NewJWKSController(jwtService), nil
+	})
+}
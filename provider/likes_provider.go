@@ -12,13 +12,15 @@ import (
 func ProvideLikesDependencies(injector *do.Injector) {
 	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	notificationService := do.MustInvokeNamed[service.NotificationService](injector, constants.NotificationService)
 
 	// Repository
 	likesRepository := repository.NewLikesRepository(db)
 	postRepository := repository.NewPostRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
 
 	// Service
-	likesService := service.NewLikesService(likesRepository, postRepository, jwtService)
+	likesService := service.NewLikesService(likesRepository, postRepository, blockRepository, notificationService, jwtService)
 
 	// Controller
 	do.Provide(injector, func(i *do.Injector) (controller.LikesController, error) {
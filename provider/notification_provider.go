@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideNotificationDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+
+	// Repository
+	notificationRepository := repository.NewNotificationRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+
+	// Service
+	notificationService := service.NewNotificationService(notificationRepository, blockRepository, storage)
+	do.ProvideNamed(injector, constants.NotificationService, func(i *do.Injector) (service.NotificationService, error) {
+		return notificationService, nil
+	})
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.NotificationController, error) {
+		return controller.NewNotificationController(notificationService), nil
+	})
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideOIDCDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	sessionService := do.MustInvokeNamed[service.SessionService](injector, constants.SessionService)
+	twoFactorService := do.MustInvokeNamed[service.TwoFactorService](injector, constants.TwoFactorService)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	oidcRepository := repository.NewOIDCRepository(db)
+
+	// Service
+	oidcService := service.NewOIDCService(userRepository, oidcRepository, sessionService, twoFactorService, config.GetOIDCConfig())
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.OIDCController, error) {
+		return controller.NewOIDCController(oidcService), nil
+	})
+}
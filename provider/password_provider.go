@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvidePasswordDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	sessionService := do.MustInvokeNamed[service.SessionService](injector, constants.SessionService)
+	mailer := do.MustInvokeNamed[utils.Mailer](injector, constants.Mailer)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	passwordResetRepository := repository.NewPasswordResetRepository(db)
+
+	// Service
+	passwordService := service.NewPasswordService(userRepository, passwordResetRepository, sessionService, mailer, config.GetPasswordResetConfig())
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.PasswordController, error) {
+		return controller.NewPasswordController(passwordService), nil
+	})
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvidePersonalAccessTokenDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+
+	// Repository
+	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(db)
+
+	// Service
+	personalAccessTokenService := service.NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.PersonalAccessTokenController, error) {
+		return controller.NewPersonalAccessTokenController(personalAccessTokenService), nil
+	})
+}
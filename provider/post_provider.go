@@ -1,24 +1,35 @@
 package provider
 
 import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
 
 func ProvidePostDependencies(injector *do.Injector) {
 	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	notificationService := do.MustInvokeNamed[service.NotificationService](injector, constants.NotificationService)
 
 	// Repository
 	userRepository := repository.NewUserRepository(db)
 	postRepository := repository.NewPostRepository(db)
+	postRevisionRepository := repository.NewPostRevisionRepository(db)
+	bookmarkRepository := repository.NewBookmarkRepository(db)
+	mentionRepository := repository.NewMentionRepository(db)
+	hashtagRepository := repository.NewHashtagRepository(db)
+	timelineRepository := repository.NewTimelineRepository(db, config.GetTimelineStrategy())
+	blockRepository := repository.NewBlockRepository(db)
+	pollRepository := repository.NewPollRepository(db)
 
 	// Service
-	postService := service.NewPostService(userRepository, postRepository, jwtService)
+	postService := service.NewPostService(userRepository, postRepository, postRevisionRepository, timelineRepository, bookmarkRepository, mentionRepository, hashtagRepository, blockRepository, pollRepository, notificationService, jwtService, config.GetPostEditConfig(), config.GetMediaConfig(), storage)
 
 	// Controller
 	do.Provide(injector, func(i *do.Injector) (controller.PostController, error) {
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideSessionDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	sessionRepository := repository.NewSessionRepository(db)
+
+	// Service
+	sessionService := service.NewSessionService(userRepository, sessionRepository, config.GetSessionConfig(), jwtService)
+	do.ProvideNamed(injector, constants.SessionService, func(i *do.Injector) (service.SessionService, error) {
+		return sessionService, nil
+	})
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.SessionController, error) {
+		return controller.NewSessionController(sessionService), nil
+	})
+}
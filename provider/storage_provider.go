@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/samber/do"
+)
+
+func ProvideStorageDependencies(injector *do.Injector) {
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.StorageController, error) {
+		return controller.NewStorageController(storage), nil
+	})
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/samber/do"
+	"gorm.io/gorm"
+)
+
+func ProvideTwoFactorDependencies(injector *do.Injector) {
+	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	sessionService := do.MustInvokeNamed[service.SessionService](injector, constants.SessionService)
+
+	// Repository
+	userRepository := repository.NewUserRepository(db)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(db)
+	twoFactorChallengeRepository := repository.NewTwoFactorChallengeRepository(db)
+
+	twoFactorConfig, err := config.GetTwoFactorConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	// Service
+	twoFactorService := service.NewTwoFactorService(userRepository, recoveryCodeRepository, twoFactorChallengeRepository, sessionService, twoFactorConfig, config.GetLoginLockoutConfig())
+	do.ProvideNamed(injector, constants.TwoFactorService, func(i *do.Injector) (service.TwoFactorService, error) {
+		return twoFactorService, nil
+	})
+
+	// Controller
+	do.Provide(injector, func(i *do.Injector) (controller.TwoFactorController, error) {
+		return controller.NewTwoFactorController(twoFactorService), nil
+	})
+}
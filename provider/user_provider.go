@@ -1,24 +1,39 @@
 package provider
 
 import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/samber/do"
 	"gorm.io/gorm"
 )
 
 func ProvideUserDependencies(injector *do.Injector) {
 	db := do.MustInvokeNamed[*gorm.DB](injector, constants.DB)
+	storage := do.MustInvokeNamed[utils.Storage](injector, constants.Storage)
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	sessionService := do.MustInvokeNamed[service.SessionService](injector, constants.SessionService)
+	twoFactorService := do.MustInvokeNamed[service.TwoFactorService](injector, constants.TwoFactorService)
+	mailer := do.MustInvokeNamed[utils.Mailer](injector, constants.Mailer)
 
 	// Repository
 	userRepository := repository.NewUserRepository(db)
 	postRepository := repository.NewPostRepository(db)
+	bookmarkRepository := repository.NewBookmarkRepository(db)
+	mentionRepository := repository.NewMentionRepository(db)
+	pollRepository := repository.NewPollRepository(db)
+	blockRepository := repository.NewBlockRepository(db)
+
+	verificationConfig, err := config.GetVerificationConfig()
+	if err != nil {
+		panic(err)
+	}
 
 	// Service
-	userService := service.NewUserService(userRepository, postRepository, jwtService)
+	userService := service.NewUserService(userRepository, postRepository, bookmarkRepository, mentionRepository, pollRepository, blockRepository, sessionService, twoFactorService, mailer, verificationConfig, config.GetLoginLockoutConfig(), jwtService, config.GetMediaConfig(), storage)
 
 	// Controller
 	do.Provide(injector, func(i *do.Injector) (controller.UserController, error) {
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	BlockRepository interface {
+		BlockUser(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) error
+		CheckBlocked(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) (bool, error)
+		CheckBlockedBetween(ctx context.Context, tx *gorm.DB, userId string, otherId string) (bool, error)
+		GetHiddenUserIds(ctx context.Context, tx *gorm.DB, viewerId string, userIds []string) ([]string, error)
+		UnblockUser(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) error
+		GetBlockedWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error)
+	}
+
+	blockRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewBlockRepository(db *gorm.DB) BlockRepository {
+	return &blockRepository{
+		db: db,
+	}
+}
+
+func (r *blockRepository) BlockUser(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	block := &entity.Block{
+		BlockerID: uuid.MustParse(blockerId),
+		BlockedID: uuid.MustParse(blockedId),
+	}
+
+	if err := tx.WithContext(ctx).Create(&block).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *blockRepository) CheckBlocked(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Block{}).Where("blocker_id = ? AND blocked_id = ?", blockerId, blockedId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CheckBlockedBetween reports whether either user has blocked the other
+func (r *blockRepository) CheckBlockedBetween(ctx context.Context, tx *gorm.DB, userId string, otherId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Block{}).Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userId, otherId, otherId, userId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetHiddenUserIds returns those of userIds the viewer blocked, was blocked
+// by or muted
+func (r *blockRepository) GetHiddenUserIds(ctx context.Context, tx *gorm.DB, viewerId string, userIds []string) ([]string, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var hiddenIds []string
+	if err := tx.WithContext(ctx).Raw(`SELECT blocked_id FROM blocks WHERE blocker_id = @viewer AND blocked_id IN @users
+		UNION SELECT blocker_id FROM blocks WHERE blocked_id = @viewer AND blocker_id IN @users
+		UNION SELECT muted_id FROM mutes WHERE muter_id = @viewer AND muted_id IN @users`,
+		sql.Named("viewer", viewerId), sql.Named("users", userIds)).Scan(&hiddenIds).Error; err != nil {
+		return nil, err
+	}
+
+	return hiddenIds, nil
+}
+
+func (r *blockRepository) UnblockUser(ctx context.Context, tx *gorm.DB, blockerId string, blockedId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("blocker_id = ? AND blocked_id = ?", blockerId, blockedId).Unscoped().Delete(&entity.Block{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *blockRepository) GetBlockedWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Joins("INNER JOIN blocks ON blocks.blocked_id = users.id").Where("blocks.blocker_id = ?", userId).Order("blocks.created_at DESC")
+
+	return paginateUsers(query, req)
+}
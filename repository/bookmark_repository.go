@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	BookmarkRepository interface {
+		BookmarkPostById(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error
+		CheckBookmarkedPost(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error
+		UnbookmarkPostById(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error
+		GetBookmarkedPostIds(ctx context.Context, tx *gorm.DB, userId string, postIds []uint64) ([]uint64, error)
+		GetBookmarksWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
+	}
+
+	bookmarkRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewBookmarkRepository(db *gorm.DB) BookmarkRepository {
+	return &bookmarkRepository{
+		db: db,
+	}
+}
+
+func (r *bookmarkRepository) BookmarkPostById(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	bookmark := &entity.Bookmark{
+		UserID: uuid.MustParse(userId),
+		PostID: postId,
+	}
+
+	if err := tx.WithContext(ctx).Create(&bookmark).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *bookmarkRepository) CheckBookmarkedPost(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var bookmark entity.Bookmark
+	if err := tx.WithContext(ctx).Where("user_id = ? AND post_id = ?", userId, postId).Take(&bookmark).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *bookmarkRepository) UnbookmarkPostById(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("user_id = ? AND post_id = ?", userId, postId).Unscoped().Delete(&entity.Bookmark{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *bookmarkRepository) GetBookmarkedPostIds(ctx context.Context, tx *gorm.DB, userId string, postIds []uint64) ([]uint64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var ids []uint64
+	if err := tx.WithContext(ctx).Model(&entity.Bookmark{}).Where("user_id = ? AND post_id IN ?", userId, postIds).Pluck("post_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *bookmarkRepository) GetBookmarksWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var posts []entity.Post
+	var err error
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Joins("INNER JOIN bookmarks ON bookmarks.post_id = posts.id").Where("bookmarks.user_id = ? AND bookmarks.deleted_at IS NULL", userId)
+	if req.Search != "" {
+		query = query.Where("posts.text LIKE ?", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	if err := query.Order("bookmarks.created_at DESC").Scopes(Paginate(req), PreloadPostRelations).Find(&posts).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllPostsRepositoryResponse{
+		Posts: posts,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
@@ -4,7 +4,9 @@ import (
 	"math"
 
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func Paginate(req dto.PaginationRequest) func(db *gorm.DB) *gorm.DB {
@@ -14,8 +16,96 @@ func Paginate(req dto.PaginationRequest) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+// PreloadPostRelations loads what a post needs to be rendered. Reposted and
+// quoted posts are loaded unscoped so deleted ones still show as tombstones.
+func PreloadPostRelations(db *gorm.DB) *gorm.DB {
+	for _, prefix := range []string{"", "RepostOf.", "QuotedPost.", "RepostOf.QuotedPost."} {
+		db = db.Preload(prefix+"Mentions", orderMentions).Preload(prefix+"Mentions.User").Preload(prefix+"Attachments", orderAttachments).Preload(prefix+"Poll.Options", orderPollOptions)
+	}
+
+	return db.Preload("RepostOf", unscoped).
+		Preload("RepostOf.User").
+		Preload("RepostOf.QuotedPost", unscoped).
+		Preload("RepostOf.QuotedPost.User").
+		Preload("QuotedPost", unscoped).
+		Preload("QuotedPost.User")
+}
+
+// HideUsersFromViewer drops posts written by users the viewer blocked or was
+// blocked by, and reposts of their posts. Users the viewer muted are dropped
+// too when hideMuted is set. Anonymous viewers see everything.
+func HideUsersFromViewer(viewerId string, hideMuted bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerId == "" {
+			return db
+		}
+
+		hidden := hiddenUserIds(viewerId, hideMuted)
+		return db.Where("posts.user_id NOT IN (?)", hidden).
+			Where("posts.repost_of_id IS NULL OR posts.repost_of_id NOT IN (SELECT id FROM posts WHERE user_id IN (?))", hidden)
+	}
+}
+
+// hiddenUserIds selects the users the viewer blocked or was blocked by, and
+// the ones the viewer muted when hideMuted is set
+func hiddenUserIds(viewerId string, hideMuted bool) clause.Expr {
+	hidden := gorm.Expr("SELECT blocked_id FROM blocks WHERE blocker_id = ? UNION SELECT blocker_id FROM blocks WHERE blocked_id = ?", viewerId, viewerId)
+	if hideMuted {
+		hidden = gorm.Expr("? UNION SELECT muted_id FROM mutes WHERE muter_id = ?", hidden, viewerId)
+	}
+
+	return hidden
+}
+
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func orderMentions(db *gorm.DB) *gorm.DB {
+	return db.Order("start_index")
+}
+
+func orderAttachments(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
+func orderPollOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
+}
+
 func TotalPage(count, perPage int64) int64 {
 	totalPage := int64(math.Ceil(float64(count) / float64(perPage)))
 
 	return totalPage
 }
+
+func paginateUsers(query *gorm.DB, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	var users []entity.User
+	var err error
+	var count int64
+
+	req.Default()
+
+	if req.Search != "" {
+		query = query.Where("users.username LIKE ? OR users.name LIKE ?", "%"+req.Search+"%", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllUsersRepositoryResponse{}, err
+	}
+
+	if err := query.Scopes(Paginate(req)).Find(&users).Error; err != nil {
+		return dto.GetAllUsersRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllUsersRepositoryResponse{
+		Users: users,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	ConversationRepository interface {
+		CreateConversation(ctx context.Context, tx *gorm.DB, conversation entity.Conversation) (entity.Conversation, error)
+		GetDirectConversation(ctx context.Context, tx *gorm.DB, userId string, otherId string) (entity.Conversation, error)
+		GetConversationById(ctx context.Context, tx *gorm.DB, conversationId uint64) (entity.Conversation, error)
+		CheckParticipant(ctx context.Context, tx *gorm.DB, conversationId uint64, userId string) (bool, error)
+		GetConversationsWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllConversationsRepositoryResponse, error)
+		CountUnreadMessages(ctx context.Context, tx *gorm.DB, userId string, conversationIds []uint64) (map[uint64]int64, error)
+		UpdateLastMessage(ctx context.Context, tx *gorm.DB, message entity.Message) error
+		UpdateReadReceipt(ctx context.Context, tx *gorm.DB, conversationId uint64, userId string, messageId uint64) error
+	}
+
+	conversationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewConversationRepository(db *gorm.DB) ConversationRepository {
+	return &conversationRepository{
+		db: db,
+	}
+}
+
+func (r *conversationRepository) CreateConversation(ctx context.Context, tx *gorm.DB, conversation entity.Conversation) (entity.Conversation, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	participants := conversation.Participants
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&conversation).Error; err != nil {
+			return err
+		}
+
+		for i := range participants {
+			participants[i].ConversationID = conversation.ID
+		}
+
+		return tx.Omit(clause.Associations).Create(&participants).Error
+	})
+	if err != nil {
+		return entity.Conversation{}, err
+	}
+
+	conversation.Participants = participants
+	return conversation, nil
+}
+
+func (r *conversationRepository) GetDirectConversation(ctx context.Context, tx *gorm.DB, userId string, otherId string) (entity.Conversation, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	joined := func(id string) *gorm.DB {
+		return tx.Model(&entity.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", id)
+	}
+
+	var conversation entity.Conversation
+	if err := tx.WithContext(ctx).Scopes(preloadConversationRelations).Where("is_group = ?", false).Where("id IN (?)", joined(userId)).Where("id IN (?)", joined(otherId)).Take(&conversation).Error; err != nil {
+		return entity.Conversation{}, err
+	}
+
+	return conversation, nil
+}
+
+func (r *conversationRepository) GetConversationById(ctx context.Context, tx *gorm.DB, conversationId uint64) (entity.Conversation, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var conversation entity.Conversation
+	if err := tx.WithContext(ctx).Scopes(preloadConversationRelations).Where("id = ?", conversationId).Take(&conversation).Error; err != nil {
+		return entity.Conversation{}, err
+	}
+
+	return conversation, nil
+}
+
+func (r *conversationRepository) CheckParticipant(ctx context.Context, tx *gorm.DB, conversationId uint64, userId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.ConversationParticipant{}).Where("conversation_id = ? AND user_id = ?", conversationId, userId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *conversationRepository) GetConversationsWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllConversationsRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var conversations []entity.Conversation
+	var err error
+	var count int64
+
+	req.Default()
+
+	joined := tx.Model(&entity.ConversationParticipant{}).Select("conversation_id").Where("user_id = ?", userId)
+	query := tx.WithContext(ctx).Model(&entity.Conversation{}).Where("id IN (?)", joined)
+	if req.Search != "" {
+		query = query.Where("name LIKE ?", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllConversationsRepositoryResponse{}, err
+	}
+
+	if err := query.Order("last_message_at DESC").Order("id DESC").Scopes(Paginate(req), preloadConversationRelations).Find(&conversations).Error; err != nil {
+		return dto.GetAllConversationsRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllConversationsRepositoryResponse{
+		Conversations: conversations,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
+
+// CountUnreadMessages counts messages from other participants sent after the
+// user's read receipt, keyed by conversation id.
+func (r *conversationRepository) CountUnreadMessages(ctx context.Context, tx *gorm.DB, userId string, conversationIds []uint64) (map[uint64]int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	unread := make(map[uint64]int64)
+	if len(conversationIds) == 0 {
+		return unread, nil
+	}
+
+	var rows []struct {
+		ConversationID uint64
+		Count          int64
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Message{}).
+		Select("messages.conversation_id, COUNT(*) AS count").
+		Joins("INNER JOIN conversation_participants ON conversation_participants.conversation_id = messages.conversation_id AND conversation_participants.user_id = ?", userId).
+		Where("messages.conversation_id IN ?", conversationIds).
+		Where("messages.sender_id <> ?", userId).
+		Where("messages.id > COALESCE(conversation_participants.last_read_message_id, 0)").
+		Group("messages.conversation_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		unread[row.ConversationID] = row.Count
+	}
+
+	return unread, nil
+}
+
+func (r *conversationRepository) UpdateLastMessage(ctx context.Context, tx *gorm.DB, message entity.Message) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Conversation{}).Where("id = ?", message.ConversationID).UpdateColumns(map[string]any{
+		"last_message_id": message.ID,
+		"last_message_at": message.CreatedAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateReadReceipt only moves the receipt forward, reading an older message
+// again doesn't mark newer ones as unread.
+func (r *conversationRepository) UpdateReadReceipt(ctx context.Context, tx *gorm.DB, conversationId uint64, userId string, messageId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.ConversationParticipant{}).
+		Where("conversation_id = ? AND user_id = ?", conversationId, userId).
+		Where("last_read_message_id IS NULL OR last_read_message_id < ?", messageId).
+		UpdateColumns(map[string]any{
+			"last_read_message_id": messageId,
+			"last_read_at":         time.Now(),
+		}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func preloadConversationRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Participants.User").Preload("LastMessage.Sender")
+}
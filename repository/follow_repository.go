@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	FollowRepository interface {
+		FollowUser(ctx context.Context, tx *gorm.DB, followerId string, followingId string) error
+		CheckFollowing(ctx context.Context, tx *gorm.DB, followerId string, followingId string) (bool, error)
+		UnfollowUser(ctx context.Context, tx *gorm.DB, followerId string, followingId string) error
+		GetFollowersWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error)
+		GetFollowingWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error)
+	}
+
+	followRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewFollowRepository(db *gorm.DB) FollowRepository {
+	return &followRepository{
+		db: db,
+	}
+}
+
+func (r *followRepository) FollowUser(ctx context.Context, tx *gorm.DB, followerId string, followingId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	follow := &entity.Follow{
+		FollowerID:  uuid.MustParse(followerId),
+		FollowingID: uuid.MustParse(followingId),
+	}
+
+	if err := tx.WithContext(ctx).Create(&follow).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *followRepository) CheckFollowing(ctx context.Context, tx *gorm.DB, followerId string, followingId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Follow{}).Where("follower_id = ? AND following_id = ?", followerId, followingId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *followRepository) UnfollowUser(ctx context.Context, tx *gorm.DB, followerId string, followingId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("follower_id = ? AND following_id = ?", followerId, followingId).Unscoped().Delete(&entity.Follow{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *followRepository) GetFollowersWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Joins("INNER JOIN follows ON follows.follower_id = users.id").Where("follows.following_id = ?", userId)
+
+	return r.paginateFollows(query, req)
+}
+
+func (r *followRepository) GetFollowingWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Joins("INNER JOIN follows ON follows.following_id = users.id").Where("follows.follower_id = ?", userId)
+
+	return r.paginateFollows(query, req)
+}
+
+// paginateFollows lists the users joined through the follows table, the most
+// recent follow first.
+func (r *followRepository) paginateFollows(query *gorm.DB, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	query = query.Where("follows.deleted_at IS NULL").Order("follows.created_at DESC")
+
+	return paginateUsers(query, req)
+}
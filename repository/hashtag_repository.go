@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	HashtagRepository interface {
+		FirstOrCreateHashtag(ctx context.Context, tx *gorm.DB, name string) (entity.Hashtag, error)
+		SetPostHashtags(ctx context.Context, tx *gorm.DB, postId uint64, hashtagIds []uint64) error
+		GetPostsByHashtagWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, name string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
+		GetTrendingHashtags(ctx context.Context, tx *gorm.DB, since time.Time, halfLife time.Duration, limit int) ([]dto.TrendResponse, error)
+	}
+
+	hashtagRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewHashtagRepository(db *gorm.DB) HashtagRepository {
+	return &hashtagRepository{
+		db: db,
+	}
+}
+
+func (r *hashtagRepository) FirstOrCreateHashtag(ctx context.Context, tx *gorm.DB, name string) (entity.Hashtag, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	hashtag := entity.Hashtag{Name: name}
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&hashtag).Error; err != nil {
+		return entity.Hashtag{}, err
+	}
+
+	if err := tx.WithContext(ctx).Where("name = ?", name).Take(&hashtag).Error; err != nil {
+		return entity.Hashtag{}, err
+	}
+
+	return hashtag, nil
+}
+
+// SetPostHashtags keeps the existing rows of tags still in use, so editing a
+// post doesn't bump its tags back to the top of the trends.
+func (r *hashtagRepository) SetPostHashtags(ctx context.Context, tx *gorm.DB, postId uint64, hashtagIds []uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Where("post_id = ?", postId)
+	if len(hashtagIds) > 0 {
+		query = query.Where("hashtag_id NOT IN ?", hashtagIds)
+	}
+
+	if err := query.Unscoped().Delete(&entity.PostHashtag{}).Error; err != nil {
+		return err
+	}
+
+	if len(hashtagIds) == 0 {
+		return nil
+	}
+
+	postHashtags := make([]entity.PostHashtag, 0, len(hashtagIds))
+	for _, hashtagId := range hashtagIds {
+		postHashtags = append(postHashtags, entity.PostHashtag{
+			PostID:    postId,
+			HashtagID: hashtagId,
+		})
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(&postHashtags).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *hashtagRepository) GetPostsByHashtagWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, name string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var posts []entity.Post
+	var err error
+	var count int64
+
+	req.Default()
+
+	tagged := tx.Model(&entity.PostHashtag{}).Select("post_hashtags.post_id").Joins("INNER JOIN hashtags ON hashtags.id = post_hashtags.hashtag_id").Where("hashtags.name = ?", name)
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Where("posts.id IN (?)", tagged).Scopes(HideUsersFromViewer(viewerId, true))
+	if req.Search != "" {
+		query = query.Where("posts.text LIKE ?", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	if err := query.Order("posts.created_at DESC").Scopes(Paginate(req), PreloadPostRelations).Find(&posts).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllPostsRepositoryResponse{
+		Posts: posts,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
+
+// GetTrendingHashtags ranks tags used since the given time, every usage
+// weighs half as much for each half life that passed since it was posted.
+func (r *hashtagRepository) GetTrendingHashtags(ctx context.Context, tx *gorm.DB, since time.Time, halfLife time.Duration, limit int) ([]dto.TrendResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	trends := make([]dto.TrendResponse, 0)
+	if err := tx.WithContext(ctx).Model(&entity.PostHashtag{}).
+		Select("hashtags.name AS name, COUNT(*) AS total_posts, SUM(POWER(0.5, EXTRACT(EPOCH FROM (NOW() - post_hashtags.created_at)) / ?)) AS score", halfLife.Seconds()).
+		Joins("INNER JOIN hashtags ON hashtags.id = post_hashtags.hashtag_id").
+		Joins("INNER JOIN posts ON posts.id = post_hashtags.post_id AND posts.deleted_at IS NULL").
+		Where("post_hashtags.created_at >= ?", since).
+		Group("hashtags.name").
+		Order("score DESC").
+		Limit(limit).
+		Scan(&trends).Error; err != nil {
+		return nil, err
+	}
+
+	return trends, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	MentionRepository interface {
+		CreateMentions(ctx context.Context, tx *gorm.DB, mentions []entity.Mention) error
+		DeleteMentionsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) error
+		GetMentionsWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
+	}
+
+	mentionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewMentionRepository(db *gorm.DB) MentionRepository {
+	return &mentionRepository{
+		db: db,
+	}
+}
+
+func (r *mentionRepository) CreateMentions(ctx context.Context, tx *gorm.DB, mentions []entity.Mention) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&mentions).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mentionRepository) DeleteMentionsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("post_id = ?", postId).Unscoped().Delete(&entity.Mention{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *mentionRepository) GetMentionsWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var posts []entity.Post
+	var err error
+	var count int64
+
+	req.Default()
+
+	mentioned := tx.Model(&entity.Mention{}).Select("post_id").Where("user_id = ?", userId)
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Where("posts.id IN (?)", mentioned).Scopes(HideUsersFromViewer(userId, true))
+	if req.Search != "" {
+		query = query.Where("posts.text LIKE ?", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	if err := query.Order("posts.created_at DESC").Scopes(Paginate(req), PreloadPostRelations).Find(&posts).Error; err != nil {
+		return dto.GetAllPostsRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllPostsRepositoryResponse{
+		Posts: posts,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	MessageRepository interface {
+		CreateMessage(ctx context.Context, tx *gorm.DB, message entity.Message) (entity.Message, error)
+		GetMessageById(ctx context.Context, tx *gorm.DB, conversationId uint64, messageId uint64) (entity.Message, error)
+		GetLatestMessage(ctx context.Context, tx *gorm.DB, conversationId uint64) (entity.Message, error)
+		GetMessagesWithCursor(ctx context.Context, tx *gorm.DB, conversationId uint64, req dto.MessageCursorRequest) ([]entity.Message, bool, error)
+	}
+
+	messageRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewMessageRepository(db *gorm.DB) MessageRepository {
+	return &messageRepository{
+		db: db,
+	}
+}
+
+func (r *messageRepository) CreateMessage(ctx context.Context, tx *gorm.DB, message entity.Message) (entity.Message, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&message).Error; err != nil {
+		return entity.Message{}, err
+	}
+
+	return message, nil
+}
+
+func (r *messageRepository) GetMessageById(ctx context.Context, tx *gorm.DB, conversationId uint64, messageId uint64) (entity.Message, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var message entity.Message
+	if err := tx.WithContext(ctx).Preload("Sender").Where("conversation_id = ? AND id = ?", conversationId, messageId).Take(&message).Error; err != nil {
+		return entity.Message{}, err
+	}
+
+	return message, nil
+}
+
+func (r *messageRepository) GetLatestMessage(ctx context.Context, tx *gorm.DB, conversationId uint64) (entity.Message, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var message entity.Message
+	if err := tx.WithContext(ctx).Where("conversation_id = ?", conversationId).Order("id DESC").Take(&message).Error; err != nil {
+		return entity.Message{}, err
+	}
+
+	return message, nil
+}
+
+// GetMessagesWithCursor returns messages older than the cursor, newest first,
+// and whether there are more to load.
+func (r *messageRepository) GetMessagesWithCursor(ctx context.Context, tx *gorm.DB, conversationId uint64, req dto.MessageCursorRequest) ([]entity.Message, bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Preload("Sender").Where("conversation_id = ?", conversationId)
+	if req.Cursor != 0 {
+		query = query.Where("id < ?", req.Cursor)
+	}
+
+	var messages []entity.Message
+	if err := query.Order("id DESC").Limit(req.Limit + 1).Find(&messages).Error; err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > req.Limit
+	if hasMore {
+		messages = messages[:req.Limit]
+	}
+
+	return messages, hasMore, nil
+}
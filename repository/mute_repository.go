@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	MuteRepository interface {
+		MuteUser(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) error
+		CheckMuted(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) (bool, error)
+		UnmuteUser(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) error
+		GetMutedWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error)
+	}
+
+	muteRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewMuteRepository(db *gorm.DB) MuteRepository {
+	return &muteRepository{
+		db: db,
+	}
+}
+
+func (r *muteRepository) MuteUser(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	mute := &entity.Mute{
+		MuterID: uuid.MustParse(muterId),
+		MutedID: uuid.MustParse(mutedId),
+	}
+
+	if err := tx.WithContext(ctx).Create(&mute).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *muteRepository) CheckMuted(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Mute{}).Where("muter_id = ? AND muted_id = ?", muterId, mutedId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *muteRepository) UnmuteUser(ctx context.Context, tx *gorm.DB, muterId string, mutedId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("muter_id = ? AND muted_id = ?", muterId, mutedId).Unscoped().Delete(&entity.Mute{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *muteRepository) GetMutedWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Joins("INNER JOIN mutes ON mutes.muted_id = users.id").Where("mutes.muter_id = ?", userId).Order("mutes.created_at DESC")
+
+	return paginateUsers(query, req)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// number of most recent actors loaded for each notification group
+const notificationGroupActors = 3
+
+type (
+	NotificationRepository interface {
+		CreateNotification(ctx context.Context, tx *gorm.DB, notification entity.Notification) error
+		DeleteNotification(ctx context.Context, tx *gorm.DB, recipientId string, actorId string, groupKey string) error
+		GetNotificationsWithPagination(ctx context.Context, tx *gorm.DB, recipientId string, req dto.PaginationRequest) (dto.GetAllNotificationsRepositoryResponse, error)
+		CountUnreadGroups(ctx context.Context, tx *gorm.DB, recipientId string) (int64, error)
+		MarkAsRead(ctx context.Context, tx *gorm.DB, recipientId string, groupKey string) error
+	}
+
+	notificationRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRepository) CreateNotification(ctx context.Context, tx *gorm.DB, notification entity.Notification) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&notification).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) DeleteNotification(ctx context.Context, tx *gorm.DB, recipientId string, actorId string, groupKey string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("recipient_id = ? AND actor_id = ? AND group_key = ?", recipientId, actorId, groupKey).Unscoped().Delete(&entity.Notification{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNotificationsWithPagination pages over groups rather than single events,
+// unread events are grouped apart from the ones already read. Events by users
+// the recipient blocked, was blocked by or muted are left out.
+func (r *notificationRepository) GetNotificationsWithPagination(ctx context.Context, tx *gorm.DB, recipientId string, req dto.PaginationRequest) (dto.GetAllNotificationsRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var groups []dto.NotificationGroup
+	var err error
+	var count int64
+
+	req.Default()
+
+	query := tx.WithContext(ctx).Model(&entity.Notification{}).
+		Select("group_key, type, post_id, read_at IS NOT NULL AS is_read, COUNT(DISTINCT actor_id) AS total_actors, MAX(created_at) AS latest_at").
+		Where("recipient_id = ? AND actor_id NOT IN (?)", recipientId, hiddenUserIds(recipientId, true)).
+		Group("group_key, type, post_id, read_at IS NOT NULL")
+
+	if err := tx.WithContext(ctx).Table("(?) AS notification_groups", query).Count(&count).Error; err != nil {
+		return dto.GetAllNotificationsRepositoryResponse{}, err
+	}
+
+	if err := query.Order("latest_at DESC").Scopes(Paginate(req)).Scan(&groups).Error; err != nil {
+		return dto.GetAllNotificationsRepositoryResponse{}, err
+	}
+
+	if err := r.loadGroupActors(ctx, tx, recipientId, groups); err != nil {
+		return dto.GetAllNotificationsRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetAllNotificationsRepositoryResponse{
+		Groups: groups,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    req.Page,
+			PerPage: req.PerPage,
+			Count:   count,
+			MaxPage: totalPage,
+		},
+	}, err
+}
+
+// loadGroupActors fills every group with its latest events in a single query
+func (r *notificationRepository) loadGroupActors(ctx context.Context, tx *gorm.DB, recipientId string, groups []dto.NotificationGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	groupKeys := make([]string, 0, len(groups))
+	for _, group := range groups {
+		groupKeys = append(groupKeys, group.GroupKey)
+	}
+
+	ranked := tx.Model(&entity.Notification{}).
+		Select("notifications.*, ROW_NUMBER() OVER (PARTITION BY group_key, read_at IS NOT NULL ORDER BY created_at DESC) AS actor_rank").
+		Where("recipient_id = ? AND group_key IN ? AND actor_id NOT IN (?)", recipientId, groupKeys, hiddenUserIds(recipientId, true))
+
+	var notifications []entity.Notification
+	if err := tx.WithContext(ctx).Table("(?) AS notifications", ranked).
+		Where("actor_rank <= ?", notificationGroupActors).
+		Order("created_at DESC").
+		Preload("Actor").
+		Preload("Post").
+		Preload("Post.User").
+		Find(&notifications).Error; err != nil {
+		return err
+	}
+
+	for i := range groups {
+		for _, notification := range notifications {
+			if notification.GroupKey == groups[i].GroupKey && (notification.ReadAt != nil) == groups[i].IsRead {
+				groups[i].Actors = append(groups[i].Actors, notification)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) CountUnreadGroups(ctx context.Context, tx *gorm.DB, recipientId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Notification{}).Where("recipient_id = ? AND read_at IS NULL AND actor_id NOT IN (?)", recipientId, hiddenUserIds(recipientId, true)).Distinct("group_key").Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkAsRead marks a single group as read, or every notification when the
+// group key is empty.
+func (r *notificationRepository) MarkAsRead(ctx context.Context, tx *gorm.DB, recipientId string, groupKey string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Notification{}).Where("recipient_id = ? AND read_at IS NULL", recipientId)
+	if groupKey != "" {
+		query = query.Where("group_key = ?", groupKey)
+	}
+
+	if err := query.UpdateColumn("read_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
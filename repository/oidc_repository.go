@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	OIDCRepository interface {
+		CreateOIDCState(ctx context.Context, tx *gorm.DB, state entity.OIDCState) error
+		GetOIDCStateByHash(ctx context.Context, tx *gorm.DB, stateHash string) (entity.OIDCState, error)
+		MarkOIDCStateUsed(ctx context.Context, tx *gorm.DB, stateId uint64) (bool, error)
+		GetUserIdentity(ctx context.Context, tx *gorm.DB, provider string, subject string) (entity.UserIdentity, bool, error)
+		CreateUserIdentity(ctx context.Context, tx *gorm.DB, identity entity.UserIdentity) error
+		RegisterUserWithIdentity(ctx context.Context, tx *gorm.DB, user entity.User, identity entity.UserIdentity) (entity.User, error)
+	}
+
+	oidcRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewOIDCRepository(db *gorm.DB) OIDCRepository {
+	return &oidcRepository{
+		db: db,
+	}
+}
+
+func (r *oidcRepository) CreateOIDCState(ctx context.Context, tx *gorm.DB, state entity.OIDCState) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&state).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *oidcRepository) GetOIDCStateByHash(ctx context.Context, tx *gorm.DB, stateHash string) (entity.OIDCState, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var state entity.OIDCState
+	if err := tx.WithContext(ctx).Where("state_hash = ?", stateHash).Take(&state).Error; err != nil {
+		return entity.OIDCState{}, err
+	}
+
+	return state, nil
+}
+
+// MarkOIDCStateUsed reports false when the state was already used, so a
+// callback can't be replayed.
+func (r *oidcRepository) MarkOIDCStateUsed(ctx context.Context, tx *gorm.DB, stateId uint64) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.OIDCState{}).Where("id = ? AND used_at IS NULL", stateId).UpdateColumn("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// GetUserIdentity reports false without an error when the provider account
+// isn't linked to anyone yet.
+func (r *oidcRepository) GetUserIdentity(ctx context.Context, tx *gorm.DB, provider string, subject string) (entity.UserIdentity, bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var identity entity.UserIdentity
+	err := tx.WithContext(ctx).Joins("User").Where("user_identities.provider = ? AND user_identities.subject = ?", provider, subject).Take(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.UserIdentity{}, false, nil
+	}
+
+	if err != nil {
+		return entity.UserIdentity{}, false, err
+	}
+
+	return identity, true, nil
+}
+
+func (r *oidcRepository) CreateUserIdentity(ctx context.Context, tx *gorm.DB, identity entity.UserIdentity) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&identity).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterUserWithIdentity creates the user and links the provider account in
+// one transaction, a failed link doesn't leave an account behind.
+func (r *oidcRepository) RegisterUserWithIdentity(ctx context.Context, tx *gorm.DB, user entity.User, identity entity.UserIdentity) (entity.User, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		identity.UserID = user.ID
+		return tx.Omit(clause.Associations).Create(&identity).Error
+	})
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PasswordResetRepository interface {
+		CreatePasswordReset(ctx context.Context, tx *gorm.DB, reset entity.PasswordReset) error
+		GetPasswordResetByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PasswordReset, error)
+		MarkPasswordResetUsed(ctx context.Context, tx *gorm.DB, resetId uint64) (bool, error)
+		InvalidatePasswordResets(ctx context.Context, tx *gorm.DB, userId string) error
+	}
+
+	passwordResetRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+func (r *passwordResetRepository) CreatePasswordReset(ctx context.Context, tx *gorm.DB, reset entity.PasswordReset) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&reset).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *passwordResetRepository) GetPasswordResetByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PasswordReset, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var reset entity.PasswordReset
+	if err := tx.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&reset).Error; err != nil {
+		return entity.PasswordReset{}, err
+	}
+
+	return reset, nil
+}
+
+// MarkPasswordResetUsed reports false when the token was already used, like
+// MarkRefreshTokenUsed the check and the update are one statement.
+func (r *passwordResetRepository) MarkPasswordResetUsed(ctx context.Context, tx *gorm.DB, resetId uint64) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.PasswordReset{}).Where("id = ? AND used_at IS NULL", resetId).UpdateColumn("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidatePasswordResets uses up every pending token of the user
+func (r *passwordResetRepository) InvalidatePasswordResets(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.PasswordReset{}).Where("user_id = ? AND used_at IS NULL", userId).UpdateColumn("used_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
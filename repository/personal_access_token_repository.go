@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PersonalAccessTokenRepository interface {
+		CreatePersonalAccessToken(ctx context.Context, tx *gorm.DB, token entity.PersonalAccessToken) (entity.PersonalAccessToken, error)
+		GetActivePersonalAccessTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PersonalAccessToken, error)
+		GetActivePersonalAccessTokensByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.PersonalAccessToken, error)
+		CountActivePersonalAccessTokens(ctx context.Context, tx *gorm.DB, userId string) (int64, error)
+		RevokePersonalAccessToken(ctx context.Context, tx *gorm.DB, userId string, tokenId string) (bool, error)
+		TouchPersonalAccessToken(ctx context.Context, tx *gorm.DB, tokenId string, usedAt time.Time, touchedSince time.Time) error
+	}
+
+	personalAccessTokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{
+		db: db,
+	}
+}
+
+func (r *personalAccessTokenRepository) CreatePersonalAccessToken(ctx context.Context, tx *gorm.DB, token entity.PersonalAccessToken) (entity.PersonalAccessToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&token).Error; err != nil {
+		return entity.PersonalAccessToken{}, err
+	}
+
+	return token, nil
+}
+
+// GetActivePersonalAccessTokenByHash loads the owner along with the token so
+// the caller can check the account is still in good standing.
+func (r *personalAccessTokenRepository) GetActivePersonalAccessTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.PersonalAccessToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var token entity.PersonalAccessToken
+	if err := tx.WithContext(ctx).Joins("User").Scopes(activePersonalAccessTokens).Where("personal_access_tokens.token_hash = ?", tokenHash).Take(&token).Error; err != nil {
+		return entity.PersonalAccessToken{}, err
+	}
+
+	return token, nil
+}
+
+func (r *personalAccessTokenRepository) GetActivePersonalAccessTokensByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.PersonalAccessToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var tokens []entity.PersonalAccessToken
+	if err := tx.WithContext(ctx).Scopes(activePersonalAccessTokens).Where("personal_access_tokens.user_id = ?", userId).Order("personal_access_tokens.created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *personalAccessTokenRepository) CountActivePersonalAccessTokens(ctx context.Context, tx *gorm.DB, userId string) (int64, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.PersonalAccessToken{}).Scopes(activePersonalAccessTokens).Where("personal_access_tokens.user_id = ?", userId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// RevokePersonalAccessToken reports false when the user has no such active token
+func (r *personalAccessTokenRepository) RevokePersonalAccessToken(ctx context.Context, tx *gorm.DB, userId string, tokenId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.PersonalAccessToken{}).Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenId, userId).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// TouchPersonalAccessToken only writes when the last recorded use is older
+// than touchedSince, so a busy bot doesn't update the row on every request.
+func (r *personalAccessTokenRepository) TouchPersonalAccessToken(ctx context.Context, tx *gorm.DB, tokenId string, usedAt time.Time, touchedSince time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.PersonalAccessToken{}).Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", tokenId, touchedSince).UpdateColumn("last_used_at", usedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func activePersonalAccessTokens(db *gorm.DB) *gorm.DB {
+	return db.Where("personal_access_tokens.revoked_at IS NULL AND (personal_access_tokens.expires_at IS NULL OR personal_access_tokens.expires_at > ?)", time.Now())
+}
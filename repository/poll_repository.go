@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PollRepository interface {
+		GetPollByPostId(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Poll, error)
+		GetPollsByIds(ctx context.Context, tx *gorm.DB, pollIds []string) ([]entity.Poll, error)
+		GetVotesByUserId(ctx context.Context, tx *gorm.DB, userId string, pollIds []string) ([]entity.PollVote, error)
+		Vote(ctx context.Context, tx *gorm.DB, pollId string, optionId string, userId string) (bool, error)
+	}
+
+	pollRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPollRepository(db *gorm.DB) PollRepository {
+	return &pollRepository{
+		db: db,
+	}
+}
+
+func (r *pollRepository) GetPollByPostId(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Poll, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var poll entity.Poll
+	if err := tx.WithContext(ctx).Preload("Options", orderPollOptions).Where("post_id = ?", postId).Take(&poll).Error; err != nil {
+		return entity.Poll{}, err
+	}
+
+	return poll, nil
+}
+
+func (r *pollRepository) GetPollsByIds(ctx context.Context, tx *gorm.DB, pollIds []string) ([]entity.Poll, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var polls []entity.Poll
+	if err := tx.WithContext(ctx).Preload("Options", orderPollOptions).Where("id IN ?", pollIds).Find(&polls).Error; err != nil {
+		return nil, err
+	}
+
+	return polls, nil
+}
+
+func (r *pollRepository) GetVotesByUserId(ctx context.Context, tx *gorm.DB, userId string, pollIds []string) ([]entity.PollVote, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var votes []entity.PollVote
+	if err := tx.WithContext(ctx).Where("user_id = ? AND poll_id IN ?", userId, pollIds).Find(&votes).Error; err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+// Vote records the vote and counts it in one transaction. It reports false
+// when the user already voted, the key on poll and user makes a concurrent
+// second vote wait for the first and then insert nothing.
+func (r *pollRepository) Vote(ctx context.Context, tx *gorm.DB, pollId string, optionId string, userId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	voted := false
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.PollVote{
+			PollID:   uuid.MustParse(pollId),
+			UserID:   uuid.MustParse(userId),
+			OptionID: uuid.MustParse(optionId),
+		})
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		if err := tx.Model(&entity.PollOption{}).Where("id = ? AND poll_id = ?", optionId, pollId).
+			Update("total_votes", gorm.Expr("total_votes + 1")).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Poll{}).Where("id = ?", pollId).
+			Update("total_votes", gorm.Expr("total_votes + 1")).Error; err != nil {
+			return err
+		}
+
+		voted = true
+		return nil
+	})
+
+	return voted, err
+}
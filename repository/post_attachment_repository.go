@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	PostAttachmentRepository interface {
+		GetAttachmentPathsByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]string, error)
+		GetAttachmentPathsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) ([]string, error)
+	}
+
+	postAttachmentRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPostAttachmentRepository(db *gorm.DB) PostAttachmentRepository {
+	return &postAttachmentRepository{
+		db: db,
+	}
+}
+
+// GetAttachmentPathsByUserId includes attachments of deleted posts, their
+// files are kept until the account is purged.
+func (r *postAttachmentRepository) GetAttachmentPathsByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]string, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var paths []string
+	if err := tx.WithContext(ctx).Model(&entity.PostAttachment{}).
+		Where("post_id IN (?)", tx.Unscoped().Model(&entity.Post{}).Select("id").Where("user_id = ?", userId)).
+		Pluck("path", &paths).Error; err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
+
+func (r *postAttachmentRepository) GetAttachmentPathsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) ([]string, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var paths []string
+	if err := tx.WithContext(ctx).Model(&entity.PostAttachment{}).Where("post_id = ?", postId).Pluck("path", &paths).Error; err != nil {
+		return nil, err
+	}
+
+	return paths, nil
+}
@@ -2,22 +2,29 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	PostRepository interface {
 		CreatePost(ctx context.Context, tx *gorm.DB, post entity.Post) (entity.Post, error)
 		GetPostById(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Post, error)
+		GetPostByIdWithDeleted(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Post, error)
 		DeletePostById(ctx context.Context, tx *gorm.DB, postId uint64) error
+		ForceDeletePost(ctx context.Context, tx *gorm.DB, postId uint64) error
 		UpdatePostById(ctx context.Context, tx *gorm.DB, postId uint64, post entity.Post) (entity.Post, error)
-		GetAllPostsWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
-		GetAllPostsWithPaginationByUsername(ctx context.Context, tx *gorm.DB, username string, req dto.UserPostsPaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
-		GetAllPostRepliesWithPagination(ctx context.Context, tx *gorm.DB, postId uint64, req dto.PaginationRequest) (dto.GetAllRepliesRepositoryResponse, error)
+		GetAllPostsWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
+		GetAllPostsWithPaginationByUsername(ctx context.Context, tx *gorm.DB, viewerId string, username string, req dto.UserPostsPaginationRequest) (dto.GetAllPostsRepositoryResponse, error)
+		GetAllPostRepliesWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, postId uint64, req dto.PaginationRequest) (dto.GetAllRepliesRepositoryResponse, error)
 		UpdateLikesCount(ctx context.Context, tx *gorm.DB, postId uint64, count int) error
+		GetRepostByUserId(ctx context.Context, tx *gorm.DB, postId uint64, userId string) (entity.Post, error)
+		DeleteRepostById(ctx context.Context, tx *gorm.DB, repostId uint64) error
+		UpdateRepostsCount(ctx context.Context, tx *gorm.DB, postId uint64, count int) error
 	}
 
 	postRepository struct {
@@ -49,7 +56,7 @@ func (r *postRepository) GetPostById(ctx context.Context, tx *gorm.DB, postId ui
 	}
 
 	var post entity.Post
-	if err := tx.WithContext(ctx).Joins("User").Where("posts.id = ?", postId).Take(&post).Error; err != nil {
+	if err := tx.WithContext(ctx).Joins("User").Scopes(PreloadPostRelations).Where("posts.id = ?", postId).Take(&post).Error; err != nil {
 		return entity.Post{
 			ID:       post.ID,
 			Text:     post.Text,
@@ -67,6 +74,20 @@ func (r *postRepository) GetPostById(ctx context.Context, tx *gorm.DB, postId ui
 	return post, nil
 }
 
+// GetPostByIdWithDeleted returns the bare post row, soft deleted or not
+func (r *postRepository) GetPostByIdWithDeleted(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Post, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var post entity.Post
+	if err := tx.WithContext(ctx).Unscoped().Where("id = ?", postId).Take(&post).Error; err != nil {
+		return entity.Post{}, err
+	}
+
+	return post, nil
+}
+
 func (r *postRepository) DeletePostById(ctx context.Context, tx *gorm.DB, postId uint64) error {
 	if tx == nil {
 		tx = r.db
@@ -79,19 +100,59 @@ func (r *postRepository) DeletePostById(ctx context.Context, tx *gorm.DB, postId
 	return nil
 }
 
+// ForceDeletePost removes the post and everything hanging off it in one
+// transaction. Reposts of it are deleted, a repost gives back its count on the
+// original, and the row itself is deleted unless replies or quotes from others
+// still point at it, then it is kept emptied and soft deleted like the posts
+// of a purged account.
+func (r *postRepository) ForceDeletePost(ctx context.Context, tx *gorm.DB, postId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	statements := []string{
+		`UPDATE posts SET total_reposts = total_reposts - 1
+			WHERE id = (SELECT repost_of_id FROM posts WHERE id = @post AND deleted_at IS NULL) AND total_reposts > 0`,
+		`DELETE FROM timelines WHERE post_id = @post OR post_id IN (SELECT id FROM posts WHERE repost_of_id = @post)`,
+		`DELETE FROM notifications WHERE post_id = @post OR post_id IN (SELECT id FROM posts WHERE repost_of_id = @post)`,
+		`DELETE FROM posts WHERE repost_of_id = @post`,
+		`DELETE FROM likes WHERE post_id = @post`,
+		`DELETE FROM bookmarks WHERE post_id = @post`,
+		`DELETE FROM mentions WHERE post_id = @post`,
+		`DELETE FROM post_hashtags WHERE post_id = @post`,
+		`DELETE FROM post_revisions WHERE post_id = @post`,
+		`DELETE FROM post_attachments WHERE post_id = @post`,
+		`DELETE FROM poll_votes WHERE poll_id IN (SELECT id FROM polls WHERE post_id = @post)`,
+		`DELETE FROM poll_options WHERE poll_id IN (SELECT id FROM polls WHERE post_id = @post)`,
+		`DELETE FROM polls WHERE post_id = @post`,
+		`UPDATE posts SET text = '', total_likes = 0, total_reposts = 0, deleted_at = COALESCE(deleted_at, NOW()) WHERE id = @post`,
+		`DELETE FROM posts WHERE id = @post AND NOT EXISTS (SELECT 1 FROM posts WHERE parent_id = @post OR quoted_post_id = @post)`,
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement, sql.Named("post", postId)).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (r *postRepository) UpdatePostById(ctx context.Context, tx *gorm.DB, postId uint64, post entity.Post) (entity.Post, error) {
 	if tx == nil {
 		tx = r.db
 	}
 
-	if err := tx.WithContext(ctx).Model(&entity.Post{}).Where("id = ?", postId).Updates(post).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&entity.Post{}).Omit(clause.Associations).Where("id = ?", postId).Updates(post).Error; err != nil {
 		return entity.Post{}, err
 	}
 
 	return post, nil
 }
 
-func (r *postRepository) GetAllPostsWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
+func (r *postRepository) GetAllPostsWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, req dto.PaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
 	if tx == nil {
 		tx = r.db
 	}
@@ -102,7 +163,7 @@ func (r *postRepository) GetAllPostsWithPagination(ctx context.Context, tx *gorm
 
 	req.Default()
 
-	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id IS NULL").Order("created_at DESC")
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id IS NULL").Scopes(HideUsersFromViewer(viewerId, true)).Order("created_at DESC")
 	if req.Search != "" {
 		query = query.Where("text LIKE ?", "%"+req.Search+"%")
 	}
@@ -111,7 +172,7 @@ func (r *postRepository) GetAllPostsWithPagination(ctx context.Context, tx *gorm
 		return dto.GetAllPostsRepositoryResponse{}, err
 	}
 
-	if err := query.Scopes(Paginate(req)).Find(&posts).Error; err != nil {
+	if err := query.Scopes(Paginate(req), PreloadPostRelations).Find(&posts).Error; err != nil {
 		return dto.GetAllPostsRepositoryResponse{}, err
 	}
 
@@ -127,7 +188,7 @@ func (r *postRepository) GetAllPostsWithPagination(ctx context.Context, tx *gorm
 	}, err
 }
 
-func (r *postRepository) GetAllPostRepliesWithPagination(ctx context.Context, tx *gorm.DB, postId uint64, req dto.PaginationRequest) (dto.GetAllRepliesRepositoryResponse, error) {
+func (r *postRepository) GetAllPostRepliesWithPagination(ctx context.Context, tx *gorm.DB, viewerId string, postId uint64, req dto.PaginationRequest) (dto.GetAllRepliesRepositoryResponse, error) {
 	if tx == nil {
 		tx = r.db
 	}
@@ -138,7 +199,7 @@ func (r *postRepository) GetAllPostRepliesWithPagination(ctx context.Context, tx
 
 	req.Default()
 
-	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id = ?", postId).Order("created_at DESC")
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id = ?", postId).Scopes(HideUsersFromViewer(viewerId, true)).Order("created_at DESC")
 	if req.Search != "" {
 		query = query.Where("text LIKE ?", "%"+req.Search+"%")
 	}
@@ -147,7 +208,7 @@ func (r *postRepository) GetAllPostRepliesWithPagination(ctx context.Context, tx
 		return dto.GetAllRepliesRepositoryResponse{}, err
 	}
 
-	if err := query.Scopes(Paginate(req)).Unscoped().Find(&replies).Error; err != nil {
+	if err := query.Scopes(Paginate(req), PreloadPostRelations).Unscoped().Find(&replies).Error; err != nil {
 		return dto.GetAllRepliesRepositoryResponse{}, err
 	}
 
@@ -175,7 +236,7 @@ func (r *postRepository) UpdateLikesCount(ctx context.Context, tx *gorm.DB, post
 	return nil
 }
 
-func (r *postRepository) GetAllPostsWithPaginationByUsername(ctx context.Context, tx *gorm.DB, username string, req dto.UserPostsPaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
+func (r *postRepository) GetAllPostsWithPaginationByUsername(ctx context.Context, tx *gorm.DB, viewerId string, username string, req dto.UserPostsPaginationRequest) (dto.GetAllPostsRepositoryResponse, error) {
 	if tx == nil {
 		tx = r.db
 	}
@@ -186,7 +247,7 @@ func (r *postRepository) GetAllPostsWithPaginationByUsername(ctx context.Context
 
 	req.Default()
 
-	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id IS NULL").Where("\"User\".username = ?", username)
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Unscoped().Where("posts.parent_id IS NULL").Where("\"User\".username = ?", username).Scopes(HideUsersFromViewer(viewerId, false))
 	if req.IsLiked {
 		query = query.Joins("INNER JOIN likes ON likes.post_id = posts.id AND likes.user_id = posts.user_id")
 	}
@@ -203,7 +264,7 @@ func (r *postRepository) GetAllPostsWithPaginationByUsername(ctx context.Context
 	if err := query.Scopes(Paginate(dto.PaginationRequest{
 		Page:    req.Page,
 		PerPage: req.PerPage,
-	})).Unscoped().Find(&posts).Error; err != nil {
+	}), PreloadPostRelations).Unscoped().Find(&posts).Error; err != nil {
 		return dto.GetAllPostsRepositoryResponse{}, err
 	}
 
@@ -218,3 +279,40 @@ func (r *postRepository) GetAllPostsWithPaginationByUsername(ctx context.Context
 		},
 	}, err
 }
+
+func (r *postRepository) GetRepostByUserId(ctx context.Context, tx *gorm.DB, postId uint64, userId string) (entity.Post, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var repost entity.Post
+	if err := tx.WithContext(ctx).Where("repost_of_id = ? AND user_id = ?", postId, userId).Take(&repost).Error; err != nil {
+		return entity.Post{}, err
+	}
+
+	return repost, nil
+}
+
+func (r *postRepository) DeleteRepostById(ctx context.Context, tx *gorm.DB, repostId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("repost_of_id IS NOT NULL").Unscoped().Delete(&entity.Post{}, repostId).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *postRepository) UpdateRepostsCount(ctx context.Context, tx *gorm.DB, postId uint64, count int) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Post{}).Where("id = ?", postId).UpdateColumn("total_reposts", gorm.Expr("total_reposts + ?", count)).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	PostRevisionRepository interface {
+		EditPost(ctx context.Context, tx *gorm.DB, post entity.Post, text string, editedAt time.Time) (bool, error)
+		GetRevisionsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) ([]entity.PostRevision, error)
+	}
+
+	postRevisionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPostRevisionRepository(db *gorm.DB) PostRevisionRepository {
+	return &postRevisionRepository{
+		db: db,
+	}
+}
+
+// EditPost keeps the current text of the post as a revision and replaces it.
+// It reports false when the post was edited since it was read, so concurrent
+// edits can't both pass the edit limit or write the same version.
+func (r *postRevisionRepository) EditPost(ctx context.Context, tx *gorm.DB, post entity.Post, text string, editedAt time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	writtenAt := post.CreatedAt
+	if post.EditedAt != nil {
+		writtenAt = *post.EditedAt
+	}
+
+	edited := false
+	err := tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Post{}).
+			Where("id = ? AND edit_count = ?", post.ID, post.EditCount).
+			Updates(map[string]any{
+				"text":       text,
+				"edit_count": gorm.Expr("edit_count + 1"),
+				"edited_at":  editedAt,
+			})
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+
+		if err := tx.Create(&entity.PostRevision{
+			PostID:    post.ID,
+			Version:   post.EditCount,
+			Text:      post.Text,
+			WrittenAt: writtenAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		edited = true
+		return nil
+	})
+
+	return edited, err
+}
+
+func (r *postRevisionRepository) GetRevisionsByPostId(ctx context.Context, tx *gorm.DB, postId uint64) ([]entity.PostRevision, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var revisions []entity.PostRevision
+	if err := tx.WithContext(ctx).Where("post_id = ?", postId).Order("version DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
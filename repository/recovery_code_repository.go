@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	RecoveryCodeRepository interface {
+		ReplaceRecoveryCodes(ctx context.Context, tx *gorm.DB, userId string, codeHashes []string) error
+		DeleteRecoveryCodes(ctx context.Context, tx *gorm.DB, userId string) error
+		UseRecoveryCode(ctx context.Context, tx *gorm.DB, userId string, codeHash string) (bool, error)
+	}
+
+	recoveryCodeRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db: db,
+	}
+}
+
+// ReplaceRecoveryCodes drops the previous codes of the user and stores the new ones
+func (r *recoveryCodeRepository) ReplaceRecoveryCodes(ctx context.Context, tx *gorm.DB, userId string, codeHashes []string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	codes := make([]entity.RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, entity.RecoveryCode{
+			CodeHash: hash,
+			UserID:   uuid.MustParse(userId),
+		})
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userId).Unscoped().Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("User").Create(&codes).Error
+	})
+}
+
+func (r *recoveryCodeRepository) DeleteRecoveryCodes(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("user_id = ?", userId).Unscoped().Delete(&entity.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UseRecoveryCode reports whether an unused code matched, it can't be used again after
+func (r *recoveryCodeRepository) UseRecoveryCode(ctx context.Context, tx *gorm.DB, userId string, codeHash string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.RecoveryCode{}).Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).UpdateColumn("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	SessionRepository interface {
+		CreateSession(ctx context.Context, tx *gorm.DB, session entity.Session) (entity.Session, error)
+		GetSessionById(ctx context.Context, tx *gorm.DB, sessionId string) (entity.Session, error)
+		CheckActiveSession(ctx context.Context, tx *gorm.DB, sessionId string) (bool, error)
+		GetActiveSessionsByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.Session, error)
+		TouchSession(ctx context.Context, tx *gorm.DB, sessionId string, meta dto.SessionMetadata, expiresAt time.Time) error
+		RevokeSession(ctx context.Context, tx *gorm.DB, sessionId string) error
+		RevokeSessionsByUserId(ctx context.Context, tx *gorm.DB, userId string) error
+		RevokeOtherSessions(ctx context.Context, tx *gorm.DB, userId string, keepSessionId string) error
+		CreateRefreshToken(ctx context.Context, tx *gorm.DB, token entity.RefreshToken) error
+		GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.RefreshToken, error)
+		MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenId uint64) (bool, error)
+	}
+
+	sessionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) CreateSession(ctx context.Context, tx *gorm.DB, session entity.Session) (entity.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&session).Error; err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) GetSessionById(ctx context.Context, tx *gorm.DB, sessionId string) (entity.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var session entity.Session
+	if err := tx.WithContext(ctx).Where("id = ?", sessionId).Take(&session).Error; err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) CheckActiveSession(ctx context.Context, tx *gorm.DB, sessionId string) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).Scopes(activeSessions).Where("id = ?", sessionId).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *sessionRepository) GetActiveSessionsByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]entity.Session, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var sessions []entity.Session
+	if err := tx.WithContext(ctx).Scopes(activeSessions).Where("user_id = ?", userId).Order("last_used_at DESC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// TouchSession records the latest device details and slides the expiry
+func (r *sessionRepository) TouchSession(ctx context.Context, tx *gorm.DB, sessionId string, meta dto.SessionMetadata, expiresAt time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).Where("id = ?", sessionId).UpdateColumns(map[string]any{
+		"user_agent":   meta.UserAgent,
+		"ip_address":   meta.IPAddress,
+		"last_used_at": time.Now(),
+		"expires_at":   expiresAt,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeSession(ctx context.Context, tx *gorm.DB, sessionId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).Where("id = ? AND revoked_at IS NULL", sessionId).UpdateColumn("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeSessionsByUserId(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).Where("user_id = ? AND revoked_at IS NULL", userId).UpdateColumn("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) RevokeOtherSessions(ctx context.Context, tx *gorm.DB, userId string, keepSessionId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Session{}).Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userId, keepSessionId).UpdateColumn("revoked_at", time.Now()).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) CreateRefreshToken(ctx context.Context, tx *gorm.DB, token entity.RefreshToken) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(&token).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *sessionRepository) GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.RefreshToken, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var token entity.RefreshToken
+	if err := tx.WithContext(ctx).Preload("Session").Where("token_hash = ?", tokenHash).Take(&token).Error; err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// MarkRefreshTokenUsed reports false when the token was already used, the
+// check and the update are one statement so concurrent refreshes can't both
+// win.
+func (r *sessionRepository) MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenId uint64) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.RefreshToken{}).Where("id = ? AND used_at IS NULL", tokenId).UpdateColumn("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func activeSessions(db *gorm.DB) *gorm.DB {
+	return db.Where("revoked_at IS NULL AND expires_at > ?", time.Now())
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// number of recent posts copied into a timeline when following someone
+const timelineBackfillLimit = 200
+
+type (
+	TimelineRepository interface {
+		AddPostToTimelines(ctx context.Context, tx *gorm.DB, post entity.Post) error
+		BackfillTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error
+		RemoveAuthorFromTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error
+		RemovePostFromTimelines(ctx context.Context, tx *gorm.DB, postId uint64) error
+		GetTimelineWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.TimelinePaginationRequest) (dto.GetTimelineRepositoryResponse, error)
+	}
+
+	fanInTimelineRepository struct {
+		db *gorm.DB
+	}
+
+	fanOutTimelineRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTimelineRepository(db *gorm.DB, strategy string) TimelineRepository {
+	if strategy == constants.ENUM_TIMELINE_FAN_OUT {
+		return &fanOutTimelineRepository{
+			db: db,
+		}
+	}
+
+	return &fanInTimelineRepository{
+		db: db,
+	}
+}
+
+func (r *fanInTimelineRepository) AddPostToTimelines(ctx context.Context, tx *gorm.DB, post entity.Post) error {
+	return nil
+}
+
+func (r *fanInTimelineRepository) BackfillTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error {
+	return nil
+}
+
+func (r *fanInTimelineRepository) RemoveAuthorFromTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error {
+	return nil
+}
+
+// RemovePostFromTimelines still deletes under fan-in, entries left over
+// from a previous fan-out setup would otherwise block hard deletes.
+func (r *fanInTimelineRepository) RemovePostFromTimelines(ctx context.Context, tx *gorm.DB, postId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return removePostFromTimelines(ctx, tx, postId)
+}
+
+func (r *fanInTimelineRepository) GetTimelineWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.TimelinePaginationRequest) (dto.GetTimelineRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	following := tx.Model(&entity.Follow{}).Select("following_id").Where("follower_id = ?", userId)
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Where("posts.parent_id IS NULL").Where("posts.user_id = ? OR posts.user_id IN (?)", userId, following)
+
+	return paginateTimeline(query, userId, req)
+}
+
+func (r *fanOutTimelineRepository) AddPostToTimelines(ctx context.Context, tx *gorm.DB, post entity.Post) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	timeline := &entity.Timeline{
+		UserID:   post.UserID,
+		PostID:   post.ID,
+		AuthorID: post.UserID,
+	}
+
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&timeline).Error; err != nil {
+		return err
+	}
+
+	if err := tx.WithContext(ctx).Exec(`INSERT INTO timelines (user_id, post_id, author_id, created_at, updated_at)
+		SELECT follower_id, ?, ?, NOW(), NOW() FROM follows WHERE following_id = ? AND deleted_at IS NULL
+		ON CONFLICT DO NOTHING`, post.ID, post.UserID, post.UserID).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *fanOutTimelineRepository) BackfillTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Exec(`INSERT INTO timelines (user_id, post_id, author_id, created_at, updated_at)
+		SELECT ?, id, user_id, NOW(), NOW() FROM posts WHERE user_id = ? AND parent_id IS NULL AND deleted_at IS NULL
+		ORDER BY created_at DESC LIMIT ?
+		ON CONFLICT DO NOTHING`, uuid.MustParse(userId), authorId, timelineBackfillLimit).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *fanOutTimelineRepository) RemoveAuthorFromTimeline(ctx context.Context, tx *gorm.DB, userId string, authorId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Where("user_id = ? AND author_id = ?", userId, authorId).Unscoped().Delete(&entity.Timeline{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *fanOutTimelineRepository) RemovePostFromTimelines(ctx context.Context, tx *gorm.DB, postId uint64) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	return removePostFromTimelines(ctx, tx, postId)
+}
+
+func (r *fanOutTimelineRepository) GetTimelineWithPagination(ctx context.Context, tx *gorm.DB, userId string, req dto.TimelinePaginationRequest) (dto.GetTimelineRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.Post{}).Joins("User").Joins("INNER JOIN timelines ON timelines.post_id = posts.id").Where("timelines.user_id = ?", userId)
+
+	return paginateTimeline(query, userId, req)
+}
+
+func removePostFromTimelines(ctx context.Context, tx *gorm.DB, postId uint64) error {
+	if err := tx.WithContext(ctx).Where("post_id = ?", postId).Unscoped().Delete(&entity.Timeline{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// paginateTimeline orders by id as a tie breaker and pins the result to
+// posts at or below the cursor, so new posts don't shift later pages. A
+// request without a cursor is pinned to the newest post, whatever its page.
+func paginateTimeline(query *gorm.DB, userId string, req dto.TimelinePaginationRequest) (dto.GetTimelineRepositoryResponse, error) {
+	var posts []entity.Post
+	var err error
+	var count int64
+
+	req.Default()
+
+	query = query.Scopes(HideUsersFromViewer(userId, true))
+	if req.Cursor == 0 {
+		var newest []entity.Post
+		if err := query.Session(&gorm.Session{}).Order("posts.created_at DESC").Order("posts.id DESC").Limit(1).Find(&newest).Error; err != nil {
+			return dto.GetTimelineRepositoryResponse{}, err
+		}
+
+		if len(newest) > 0 {
+			req.Cursor = newest[0].ID
+		}
+	}
+
+	if req.Cursor != 0 {
+		query = query.Where("posts.id <= ?", req.Cursor)
+	}
+
+	if req.Search != "" {
+		query = query.Where("posts.text LIKE ?", "%"+req.Search+"%")
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return dto.GetTimelineRepositoryResponse{}, err
+	}
+
+	if err := query.Order("posts.created_at DESC").Order("posts.id DESC").Scopes(Paginate(req.PaginationRequest), PreloadPostRelations).Find(&posts).Error; err != nil {
+		return dto.GetTimelineRepositoryResponse{}, err
+	}
+
+	totalPage := TotalPage(count, int64(req.PerPage))
+	return dto.GetTimelineRepositoryResponse{
+		GetAllPostsRepositoryResponse: dto.GetAllPostsRepositoryResponse{
+			Posts: posts,
+			PaginationResponse: dto.PaginationResponse{
+				Page:    req.Page,
+				PerPage: req.PerPage,
+				Count:   count,
+				MaxPage: totalPage,
+			},
+		},
+		Cursor: req.Cursor,
+	}, err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	TwoFactorChallengeRepository interface {
+		CreateChallenge(ctx context.Context, tx *gorm.DB, challenge entity.TwoFactorChallenge) (entity.TwoFactorChallenge, error)
+		UseChallenge(ctx context.Context, tx *gorm.DB, challengeId string, userId string, now time.Time) (bool, error)
+	}
+
+	twoFactorChallengeRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewTwoFactorChallengeRepository(db *gorm.DB) TwoFactorChallengeRepository {
+	return &twoFactorChallengeRepository{
+		db: db,
+	}
+}
+
+func (r *twoFactorChallengeRepository) CreateChallenge(ctx context.Context, tx *gorm.DB, challenge entity.TwoFactorChallenge) (entity.TwoFactorChallenge, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Create(&challenge).Error; err != nil {
+		return entity.TwoFactorChallenge{}, err
+	}
+
+	return challenge, nil
+}
+
+// UseChallenge reports false when the challenge is unknown, expired or was
+// already answered, claiming it in one statement so it only works once.
+func (r *twoFactorChallengeRepository) UseChallenge(ctx context.Context, tx *gorm.DB, challengeId string, userId string, now time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.TwoFactorChallenge{}).
+		Where("id = ? AND user_id = ? AND used_at IS NULL AND expires_at > ?", challengeId, userId, now).
+		UpdateColumn("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
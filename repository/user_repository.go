@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 	"gorm.io/gorm"
 )
@@ -12,7 +15,22 @@ type (
 		RegisterUser(ctx context.Context, tx *gorm.DB, user entity.User) (entity.User, error)
 		GetUserById(ctx context.Context, tx *gorm.DB, userId string) (entity.User, error)
 		CheckUsername(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error)
+		CheckEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error)
 		UpdateUser(ctx context.Context, tx *gorm.DB, userId string, user entity.User) (entity.User, error)
+		UpdateFollowersCount(ctx context.Context, tx *gorm.DB, userId string, count int) error
+		UpdateFollowingCount(ctx context.Context, tx *gorm.DB, userId string, count int) error
+		GetAllUsersWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error)
+		UpdateSuspendedAt(ctx context.Context, tx *gorm.DB, userId string, suspendedAt *time.Time) error
+		MarkVerificationSent(ctx context.Context, tx *gorm.DB, userId string, sentAt time.Time, throttledSince time.Time) (bool, error)
+		UpdateDeletionScheduledAt(ctx context.Context, tx *gorm.DB, userId string, scheduledAt *time.Time) error
+		GetUsersDueForPurge(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]entity.User, error)
+		PurgeUser(ctx context.Context, tx *gorm.DB, userId string) error
+		UpdateTwoFactor(ctx context.Context, tx *gorm.DB, userId string, secret *string, enabledAt *time.Time) error
+		MarkTOTPStepUsed(ctx context.Context, tx *gorm.DB, userId string, step int64) (bool, error)
+		RecordFailedLogin(ctx context.Context, tx *gorm.DB, userId string) (int, error)
+		UpdateLockedUntil(ctx context.Context, tx *gorm.DB, userId string, lockedUntil time.Time) error
+		ResetFailedLogins(ctx context.Context, tx *gorm.DB, userId string) error
+		UpdatePassword(ctx context.Context, tx *gorm.DB, userId string, password string) error
 	}
 
 	userRepository struct {
@@ -64,6 +82,19 @@ func (r *userRepository) CheckUsername(ctx context.Context, tx *gorm.DB, usernam
 	return user, true, nil
 }
 
+func (r *userRepository) CheckEmail(ctx context.Context, tx *gorm.DB, email string) (entity.User, bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var user entity.User
+	if err := tx.WithContext(ctx).Where("email = ?", email).Take(&user).Error; err != nil {
+		return entity.User{}, false, err
+	}
+
+	return user, true, nil
+}
+
 func (r *userRepository) UpdateUser(ctx context.Context, tx *gorm.DB, userId string, user entity.User) (entity.User, error) {
 	if tx == nil {
 		tx = r.db
@@ -80,3 +111,247 @@ func (r *userRepository) UpdateUser(ctx context.Context, tx *gorm.DB, userId str
 
 	return updatedUser, nil
 }
+
+func (r *userRepository) UpdateFollowersCount(ctx context.Context, tx *gorm.DB, userId string, count int) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).UpdateColumn("total_followers", gorm.Expr("total_followers + ?", count)).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) UpdateFollowingCount(ctx context.Context, tx *gorm.DB, userId string, count int) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).UpdateColumn("total_following", gorm.Expr("total_following + ?", count)).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetAllUsersWithPagination(ctx context.Context, tx *gorm.DB, req dto.PaginationRequest) (dto.GetAllUsersRepositoryResponse, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	query := tx.WithContext(ctx).Model(&entity.User{}).Order("users.created_at DESC")
+
+	return paginateUsers(query, req)
+}
+
+// UpdateSuspendedAt suspends the user, a nil time lifts the suspension
+func (r *userRepository) UpdateSuspendedAt(ctx context.Context, tx *gorm.DB, userId string, suspendedAt *time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Update("suspended_at", suspendedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarkVerificationSent records a verification email unless one was already
+// sent after throttledSince, checked in the same update so concurrent
+// requests can't both send.
+func (r *userRepository) MarkVerificationSent(ctx context.Context, tx *gorm.DB, userId string, sentAt time.Time, throttledSince time.Time) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND (verification_sent_at IS NULL OR verification_sent_at < ?)", userId, throttledSince).
+		Update("verification_sent_at", sentAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// UpdateTwoFactor stores a new secret and its state, nil for both turns two
+// factor authentication off
+func (r *userRepository) UpdateTwoFactor(ctx context.Context, tx *gorm.DB, userId string, secret *string, enabledAt *time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"two_factor_secret":     secret,
+		"two_factor_enabled_at": enabledAt,
+		"two_factor_last_step":  0,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarkTOTPStepUsed reports false when a code of this or a later time step was
+// already accepted, so a code can't be replayed.
+func (r *userRepository) MarkTOTPStepUsed(ctx context.Context, tx *gorm.DB, userId string, step int64) (bool, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	result := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ? AND two_factor_last_step < ?", userId, step).UpdateColumn("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// RecordFailedLogin increments the failed login counter in one statement so
+// concurrent attempts can't undercount, and returns the new count.
+func (r *userRepository) RecordFailedLogin(ctx context.Context, tx *gorm.DB, userId string) (int, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var attempts int
+	if err := tx.WithContext(ctx).Raw("UPDATE users SET failed_login_attempts = failed_login_attempts + 1 WHERE id = ? RETURNING failed_login_attempts", userId).Scan(&attempts).Error; err != nil {
+		return 0, err
+	}
+
+	return attempts, nil
+}
+
+func (r *userRepository) UpdateLockedUntil(ctx context.Context, tx *gorm.DB, userId string, lockedUntil time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Update("locked_until", lockedUntil).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) ResetFailedLogins(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"failed_login_attempts": 0,
+		"locked_until":          nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDeletionScheduledAt schedules the account for purging, a nil time
+// restores it
+func (r *userRepository) UpdateDeletionScheduledAt(ctx context.Context, tx *gorm.DB, userId string, scheduledAt *time.Time) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Update("deletion_scheduled_at", scheduledAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *userRepository) GetUsersDueForPurge(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]entity.User, error) {
+	if tx == nil {
+		tx = r.db
+	}
+
+	var users []entity.User
+	if err := tx.WithContext(ctx).Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).Order("deletion_scheduled_at").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// PurgeUser removes what the user left behind in one transaction. Likes,
+// reposts and follows are removed with the counters they fed, posts are
+// emptied and soft deleted so replies from others keep their thread, sessions
+// and other credentials are deleted, and the user row is anonymized and soft
+// deleted.
+func (r *userRepository) PurgeUser(ctx context.Context, tx *gorm.DB, userId string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	statements := []string{
+		`UPDATE posts SET total_likes = total_likes - 1
+			WHERE id IN (SELECT post_id FROM likes WHERE user_id = @user) AND total_likes > 0`,
+		`DELETE FROM likes WHERE user_id = @user`,
+		`UPDATE posts SET total_reposts = total_reposts - 1
+			WHERE id IN (SELECT repost_of_id FROM posts WHERE user_id = @user AND repost_of_id IS NOT NULL AND deleted_at IS NULL) AND total_reposts > 0`,
+		`UPDATE users SET total_followers = total_followers - 1
+			WHERE id IN (SELECT following_id FROM follows WHERE follower_id = @user AND deleted_at IS NULL) AND total_followers > 0`,
+		`UPDATE users SET total_following = total_following - 1
+			WHERE id IN (SELECT follower_id FROM follows WHERE following_id = @user AND deleted_at IS NULL) AND total_following > 0`,
+		`DELETE FROM follows WHERE follower_id = @user OR following_id = @user`,
+		`DELETE FROM timelines WHERE user_id = @user OR author_id = @user`,
+		`DELETE FROM bookmarks WHERE user_id = @user`,
+		`DELETE FROM notifications WHERE recipient_id = @user OR actor_id = @user`,
+		`DELETE FROM blocks WHERE blocker_id = @user OR blocked_id = @user`,
+		`DELETE FROM mutes WHERE muter_id = @user OR muted_id = @user`,
+		`DELETE FROM refresh_tokens WHERE session_id IN (SELECT id FROM sessions WHERE user_id = @user)`,
+		`DELETE FROM sessions WHERE user_id = @user`,
+		`DELETE FROM personal_access_tokens WHERE user_id = @user`,
+		`DELETE FROM recovery_codes WHERE user_id = @user`,
+		`DELETE FROM password_resets WHERE user_id = @user`,
+		`DELETE FROM two_factor_challenges WHERE user_id = @user`,
+		`DELETE FROM user_identities WHERE user_id = @user`,
+		`DELETE FROM post_revisions WHERE post_id IN (SELECT id FROM posts WHERE user_id = @user)`,
+		`DELETE FROM post_attachments WHERE post_id IN (SELECT id FROM posts WHERE user_id = @user)`,
+		`UPDATE poll_options SET total_votes = total_votes - 1
+			WHERE id IN (SELECT option_id FROM poll_votes WHERE user_id = @user) AND total_votes > 0`,
+		`UPDATE polls SET total_votes = total_votes - 1
+			WHERE id IN (SELECT poll_id FROM poll_votes WHERE user_id = @user) AND total_votes > 0`,
+		`DELETE FROM poll_votes WHERE user_id = @user OR poll_id IN (SELECT polls.id FROM polls JOIN posts ON posts.id = polls.post_id WHERE posts.user_id = @user)`,
+		`DELETE FROM poll_options WHERE poll_id IN (SELECT polls.id FROM polls JOIN posts ON posts.id = polls.post_id WHERE posts.user_id = @user)`,
+		`DELETE FROM polls WHERE post_id IN (SELECT id FROM posts WHERE user_id = @user)`,
+		`UPDATE posts SET text = '', deleted_at = COALESCE(deleted_at, NOW()) WHERE user_id = @user`,
+		`UPDATE users SET name = 'Deleted user', username = 'deleted_' || REPLACE(id::text, '-', ''),
+			bio = NULL, image_url = NULL, banner_url = NULL, email = NULL, password = '', total_followers = 0, total_following = 0,
+			deletion_scheduled_at = NULL, deleted_at = NOW()
+			WHERE id = @user`,
+	}
+
+	return tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, statement := range statements {
+			if err := tx.Exec(statement, sql.Named("user", userId)).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdatePassword stores an already hashed password, which also counts as the
+// first one set for accounts registered through an identity provider.
+func (r *userRepository) UpdatePassword(ctx context.Context, tx *gorm.DB, userId string, password string) error {
+	if tx == nil {
+		tx = r.db
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"password":       password,
+		"password_unset": false,
+	}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
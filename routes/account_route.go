@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Account(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	accountController := do.MustInvoke[controller.AccountController](injector)
+
+	routes := route.Group("/api/user/me")
+	{
+		routes.DELETE("", middleware.Authenticate(jwtService), accountController.DeleteAccount)
+		routes.POST("/restore", middleware.Authenticate(jwtService), accountController.RestoreAccount)
+	}
+}
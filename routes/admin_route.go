@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Admin(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	adminController := do.MustInvoke[controller.AdminController](injector)
+
+	routes := route.Group("/api/admin", middleware.Authenticate(jwtService), middleware.Authorize(constants.ENUM_ROLE_ADMIN))
+	{
+		// User
+		routes.GET("/users", adminController.GetUsers)
+		routes.PUT("/users/:user_id/suspend", adminController.SuspendUser)
+		routes.DELETE("/users/:user_id/suspend", adminController.UnsuspendUser)
+
+		// Post
+		routes.DELETE("/posts/:post_id", adminController.ForceDeletePost)
+	}
+}
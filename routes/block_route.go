@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Block(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	blockController := do.MustInvoke[controller.BlockController](injector)
+
+	routes := route.Group("/api/user")
+	{
+		// Block
+		routes.PUT("/:username/block", middleware.Authenticate(jwtService), blockController.BlockUser)
+		routes.DELETE("/:username/block", middleware.Authenticate(jwtService), blockController.UnblockUser)
+		routes.GET("/me/blocks", middleware.Authenticate(jwtService), blockController.GetBlocks)
+
+		// Mute
+		routes.PUT("/:username/mute", middleware.Authenticate(jwtService), blockController.MuteUser)
+		routes.DELETE("/:username/mute", middleware.Authenticate(jwtService), blockController.UnmuteUser)
+		routes.GET("/me/mutes", middleware.Authenticate(jwtService), blockController.GetMutes)
+	}
+}
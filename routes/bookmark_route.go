@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Bookmark(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	bookmarkController := do.MustInvoke[controller.BookmarkController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	interactionLimit := middleware.RateLimitByUser(rateLimitStore, "interaction", config.GetRateLimitConfig().Interaction)
+
+	routes := route.Group("/api")
+	{
+		routes.PUT("/post/:post_id/bookmark", middleware.Authenticate(jwtService), interactionLimit, bookmarkController.BookmarkPostById)
+		routes.DELETE("/post/:post_id/bookmark", middleware.Authenticate(jwtService), interactionLimit, bookmarkController.UnbookmarkPostById)
+		routes.GET("/user/me/bookmarks", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), bookmarkController.GetBookmarks)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Conversation(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	conversationController := do.MustInvoke[controller.ConversationController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	postLimit := middleware.RateLimitByUser(rateLimitStore, "post", config.GetRateLimitConfig().Post)
+
+	routes := route.Group("/api/conversations")
+	{
+		routes.POST("", middleware.Authenticate(jwtService), postLimit, conversationController.CreateConversation)
+		routes.GET("", middleware.Authenticate(jwtService), conversationController.GetConversations)
+		routes.GET("/:conversation_id", middleware.Authenticate(jwtService), conversationController.GetConversationById)
+		routes.POST("/:conversation_id/messages", middleware.Authenticate(jwtService), postLimit, conversationController.SendMessage)
+		routes.GET("/:conversation_id/messages", middleware.Authenticate(jwtService), conversationController.GetMessages)
+		routes.PUT("/:conversation_id/read", middleware.Authenticate(jwtService), conversationController.MarkAsRead)
+	}
+}
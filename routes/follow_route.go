@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Follow(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	followController := do.MustInvoke[controller.FollowController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	interactionLimit := middleware.RateLimitByUser(rateLimitStore, "interaction", config.GetRateLimitConfig().Interaction)
+
+	routes := route.Group("/api/user")
+	{
+		// Follow
+		routes.PUT("/:username/follow", middleware.Authenticate(jwtService), interactionLimit, followController.FollowUser)
+		routes.DELETE("/:username/follow", middleware.Authenticate(jwtService), interactionLimit, followController.UnfollowUser)
+		routes.GET("/:username/followers", followController.GetFollowers)
+		routes.GET("/:username/following", followController.GetFollowing)
+	}
+}
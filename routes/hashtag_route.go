@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Hashtag(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	hashtagController := do.MustInvoke[controller.HashtagController](injector)
+
+	routes := route.Group("/api")
+	{
+		routes.GET("/hashtag/:tag/posts", middleware.OptionalAuthenticate(jwtService), hashtagController.GetPostsByHashtag)
+		routes.GET("/trends", hashtagController.GetTrends)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func JWKS(route *gin.Engine, injector *do.Injector) {
+	jwksController := do.MustInvoke[controller.JWKSController](injector)
+
+	route.GET("/.well-known/jwks.json", jwksController.GetJWKS)
+}
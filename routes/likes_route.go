@@ -1,10 +1,12 @@
 package routes
 
 import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do"
 )
@@ -12,10 +14,12 @@ import (
 func Likes(route *gin.Engine, injector *do.Injector) {
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
 	likesController := do.MustInvoke[controller.LikesController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	interactionLimit := middleware.RateLimitByUser(rateLimitStore, "interaction", config.GetRateLimitConfig().Interaction)
 
 	routes := route.Group("/api/likes")
 	{
-		routes.PUT("/:post_id", middleware.Authenticate(jwtService), likesController.LikePostById)
-		routes.DELETE("/:post_id", middleware.Authenticate(jwtService), likesController.UnlikePostById)
+		routes.PUT("/:post_id", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_LIKES_WRITE), interactionLimit, likesController.LikePostById)
+		routes.DELETE("/:post_id", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_LIKES_WRITE), interactionLimit, likesController.UnlikePostById)
 	}
 }
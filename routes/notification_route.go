@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Notification(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	notificationController := do.MustInvoke[controller.NotificationController](injector)
+
+	routes := route.Group("/api/notifications")
+	{
+		routes.GET("", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), notificationController.GetNotifications)
+		routes.GET("/unread", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), notificationController.GetUnreadCount)
+		routes.PUT("/read", middleware.Authenticate(jwtService), notificationController.MarkAsRead)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func OIDC(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	oidcController := do.MustInvoke[controller.OIDCController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	authLimit := middleware.RateLimitByIP(rateLimitStore, "auth", config.GetRateLimitConfig().Auth)
+
+	routes := route.Group("/api/user/oidc")
+	{
+		routes.GET("/providers", oidcController.GetProviders)
+		routes.POST("/:provider/authorize", authLimit, oidcController.Authorize)
+		routes.POST("/:provider/callback", authLimit, oidcController.Login)
+		routes.POST("/:provider/link/authorize", middleware.Authenticate(jwtService), oidcController.AuthorizeLink)
+		routes.POST("/:provider/link", middleware.Authenticate(jwtService), oidcController.Link)
+	}
+}
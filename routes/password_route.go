@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Password(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	passwordController := do.MustInvoke[controller.PasswordController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	authLimit := middleware.RateLimitByIP(rateLimitStore, "auth", config.GetRateLimitConfig().Auth)
+
+	routes := route.Group("/api/user/password")
+	{
+		routes.PUT("", middleware.Authenticate(jwtService), passwordController.ChangePassword)
+		routes.POST("/forgot", authLimit, passwordController.ForgotPassword)
+		routes.POST("/reset", authLimit, passwordController.ResetPassword)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func PersonalAccessToken(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	personalAccessTokenController := do.MustInvoke[controller.PersonalAccessTokenController](injector)
+
+	routes := route.Group("/api/user/me/tokens")
+	{
+		routes.POST("", middleware.Authenticate(jwtService), personalAccessTokenController.CreatePersonalAccessToken)
+		routes.GET("", middleware.Authenticate(jwtService), personalAccessTokenController.GetPersonalAccessTokens)
+		routes.DELETE("/:token_id", middleware.Authenticate(jwtService), personalAccessTokenController.RevokePersonalAccessToken)
+	}
+}
@@ -1,10 +1,12 @@
 package routes
 
 import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do"
 )
@@ -12,14 +14,27 @@ import (
 func Post(route *gin.Engine, injector *do.Injector) {
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
 	postController := do.MustInvoke[controller.PostController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	postLimit := middleware.RateLimitByUser(rateLimitStore, "post", config.GetRateLimitConfig().Post)
+	mediaLimit := middleware.LimitBodySize(config.GetMediaConfig().MaxRequestSize())
+	interactionLimit := middleware.RateLimitByUser(rateLimitStore, "interaction", config.GetRateLimitConfig().Interaction)
 
 	routes := route.Group("/api/post")
 	{
 		// Post
-		routes.POST("", middleware.Authenticate(jwtService), postController.CreatePost)
-		routes.GET("/:post_id", postController.GetPostById)
-		routes.DELETE("/:post_id", middleware.Authenticate(jwtService), postController.DeletePostById)
-		routes.PUT("/:post_id", middleware.Authenticate(jwtService), postController.UpdatePostById)
-		routes.GET("", postController.GetAllPosts)
+		routes.POST("", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_POST_WRITE), postLimit, mediaLimit, postController.CreatePost)
+		routes.GET("/timeline", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), postController.GetTimeline)
+		routes.GET("/:post_id", middleware.OptionalAuthenticate(jwtService), postController.GetPostById)
+		routes.DELETE("/:post_id", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_POST_WRITE), postController.DeletePostById)
+		routes.PUT("/:post_id", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_POST_WRITE), postLimit, postController.UpdatePostById)
+		routes.GET("/:post_id/history", postController.GetPostHistory)
+		routes.GET("", middleware.OptionalAuthenticate(jwtService), postController.GetAllPosts)
+
+		// Repost
+		routes.PUT("/:post_id/repost", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_POST_WRITE), postLimit, postController.RepostPostById)
+		routes.DELETE("/:post_id/repost", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_POST_WRITE), postController.UnrepostPostById)
+
+		// Poll
+		routes.PUT("/:post_id/poll/vote", middleware.Authenticate(jwtService), interactionLimit, postController.VotePoll)
 	}
 }
@@ -9,4 +9,19 @@ func RegisterRoutes(server *gin.Engine, injector *do.Injector) {
 	User(server, injector)
 	Post(server, injector)
 	Likes(server, injector)
+	Follow(server, injector)
+	Bookmark(server, injector)
+	Hashtag(server, injector)
+	Notification(server, injector)
+	Conversation(server, injector)
+	Block(server, injector)
+	Session(server, injector)
+	Admin(server, injector)
+	Password(server, injector)
+	Account(server, injector)
+	TwoFactor(server, injector)
+	JWKS(server, injector)
+	PersonalAccessToken(server, injector)
+	OIDC(server, injector)
+	Storage(server, injector)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Session(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	sessionController := do.MustInvoke[controller.SessionController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	authLimit := middleware.RateLimitByIP(rateLimitStore, "auth", config.GetRateLimitConfig().Auth)
+
+	routes := route.Group("/api/user")
+	{
+		routes.POST("/refresh", authLimit, sessionController.Refresh)
+		routes.POST("/logout", middleware.Authenticate(jwtService), sessionController.Logout)
+		routes.POST("/logout-all", middleware.Authenticate(jwtService), sessionController.LogoutAll)
+		routes.GET("/me/sessions", middleware.Authenticate(jwtService), sessionController.GetSessions)
+		routes.DELETE("/me/sessions/:session_id", middleware.Authenticate(jwtService), sessionController.RevokeSession)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func Storage(route *gin.Engine, injector *do.Injector) {
+	storageController := do.MustInvoke[controller.StorageController](injector)
+
+	route.GET("/assets/*key", storageController.GetFile)
+}
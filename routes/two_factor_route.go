@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do"
+)
+
+func TwoFactor(route *gin.Engine, injector *do.Injector) {
+	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
+	twoFactorController := do.MustInvoke[controller.TwoFactorController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	authLimit := middleware.RateLimitByIP(rateLimitStore, "auth", config.GetRateLimitConfig().Auth)
+
+	routes := route.Group("/api/user")
+	{
+		routes.POST("/login/2fa", authLimit, twoFactorController.Login)
+		routes.POST("/2fa/setup", middleware.Authenticate(jwtService), twoFactorController.Setup)
+		routes.POST("/2fa/confirm", middleware.Authenticate(jwtService), twoFactorController.Confirm)
+		routes.DELETE("/2fa", middleware.Authenticate(jwtService), twoFactorController.Disable)
+	}
+}
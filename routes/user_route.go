@@ -1,10 +1,12 @@
 package routes
 
 import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/controller"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do"
 )
@@ -12,16 +14,24 @@ import (
 func User(route *gin.Engine, injector *do.Injector) {
 	jwtService := do.MustInvokeNamed[service.JWTService](injector, constants.JWTService)
 	userController := do.MustInvoke[controller.UserController](injector)
+	rateLimitStore := do.MustInvokeNamed[utils.RateLimitStore](injector, constants.RateLimitStore)
+	authLimit := middleware.RateLimitByIP(rateLimitStore, "auth", config.GetRateLimitConfig().Auth)
+	lookupLimit := middleware.RateLimitByIP(rateLimitStore, "lookup", config.GetRateLimitConfig().Lookup)
+	profileLimit := middleware.LimitBodySize(config.GetMediaConfig().MaxProfileRequestSize())
 
 	routes := route.Group("/api/user")
 	{
 		// User
-		routes.POST("/register", userController.Register)
-		routes.POST("/login", userController.Login)
-		routes.POST("/check-username", userController.CheckUsername)
-		routes.GET("/me", middleware.Authenticate(jwtService), userController.Me)
-		routes.GET("/:username", userController.GetUserByUsername)
-		routes.GET("/:username/posts", userController.GetUserPosts)
-		routes.PATCH("/update", middleware.Authenticate(jwtService), userController.UpdateUser)
+		routes.POST("/register", authLimit, userController.Register)
+		routes.POST("/login", authLimit, userController.Login)
+		routes.POST("/check-username", lookupLimit, userController.CheckUsername)
+		routes.GET("/verify-email", userController.VerifyEmail)
+		routes.POST("/verify-email", userController.VerifyEmail)
+		routes.POST("/verify-email/resend", middleware.Authenticate(jwtService), userController.ResendVerification)
+		routes.GET("/me", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), userController.Me)
+		routes.GET("/me/mentions", middleware.Authenticate(jwtService, constants.ENUM_SCOPE_READ), userController.GetMentions)
+		routes.GET("/:username", lookupLimit, userController.GetUserByUsername)
+		routes.GET("/:username/posts", middleware.OptionalAuthenticate(jwtService), userController.GetUserPosts)
+		routes.PATCH("/update", middleware.Authenticate(jwtService), profileLimit, userController.UpdateUser)
 	}
 }
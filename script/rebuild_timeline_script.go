@@ -0,0 +1,45 @@
+package script
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type (
+	RebuildTimelineScript struct {
+		db *gorm.DB
+	}
+)
+
+func NewRebuildTimelineScript(db *gorm.DB) *RebuildTimelineScript {
+	return &RebuildTimelineScript{
+		db: db,
+	}
+}
+
+// Run fills the timelines table from existing posts and follows, needed
+// when switching TIMELINE_STRATEGY to fanout on a database with data.
+func (s *RebuildTimelineScript) Run() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		own := tx.Exec(`INSERT INTO timelines (user_id, post_id, author_id, created_at, updated_at)
+			SELECT posts.user_id, posts.id, posts.user_id, NOW(), NOW() FROM posts
+			WHERE posts.parent_id IS NULL AND posts.deleted_at IS NULL
+			ON CONFLICT DO NOTHING`)
+		if own.Error != nil {
+			return own.Error
+		}
+
+		followed := tx.Exec(`INSERT INTO timelines (user_id, post_id, author_id, created_at, updated_at)
+			SELECT follows.follower_id, posts.id, posts.user_id, NOW(), NOW() FROM posts
+			INNER JOIN follows ON follows.following_id = posts.user_id AND follows.deleted_at IS NULL
+			WHERE posts.parent_id IS NULL AND posts.deleted_at IS NULL
+			ON CONFLICT DO NOTHING`)
+		if followed.Error != nil {
+			return followed.Error
+		}
+
+		fmt.Printf("timeline rebuilt with %d entries\n", own.RowsAffected+followed.RowsAffected)
+		return nil
+	})
+}
@@ -11,6 +11,12 @@ func Script(scriptName string, db *gorm.DB) error {
 	case "example_script":
 		exampleScript := NewExampleScript(db)
 		return exampleScript.Run()
+	case "rebuild_timeline":
+		rebuildTimelineScript := NewRebuildTimelineScript(db)
+		return rebuildTimelineScript.Run()
+	case "verify_existing_users":
+		verifyExistingUsersScript := NewVerifyExistingUsersScript(db)
+		return verifyExistingUsersScript.Run()
 	default:
 		return errors.New("script not found")
 	}
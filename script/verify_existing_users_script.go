@@ -0,0 +1,32 @@
+package script
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+type (
+	VerifyExistingUsersScript struct {
+		db *gorm.DB
+	}
+)
+
+func NewVerifyExistingUsersScript(db *gorm.DB) *VerifyExistingUsersScript {
+	return &VerifyExistingUsersScript{
+		db: db,
+	}
+}
+
+// Run marks accounts registered before email verification existed as
+// verified, they have no email to confirm and could not post otherwise.
+func (s *VerifyExistingUsersScript) Run() error {
+	result := s.db.Exec(`UPDATE users SET email_verified_at = created_at
+		WHERE email IS NULL AND email_verified_at IS NULL AND deleted_at IS NULL`)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	fmt.Printf("%d users marked as verified\n", result.RowsAffected)
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	AccountService interface {
+		DeleteAccount(ctx context.Context, userId string, req dto.AccountDeleteRequest) (dto.AccountDeleteResponse, error)
+		RestoreAccount(ctx context.Context, userId string) error
+		PurgeDeletedAccounts(ctx context.Context) (int, error)
+	}
+
+	accountService struct {
+		userRepo           repository.UserRepository
+		postAttachmentRepo repository.PostAttachmentRepository
+		sessionService     SessionService
+		storage            utils.Storage
+		accountConfig      config.AccountConfig
+	}
+)
+
+func NewAccountService(userRepo repository.UserRepository, postAttachmentRepo repository.PostAttachmentRepository, sessionService SessionService, storage utils.Storage, accountConfig config.AccountConfig) AccountService {
+	return &accountService{
+		userRepo:           userRepo,
+		postAttachmentRepo: postAttachmentRepo,
+		sessionService:     sessionService,
+		storage:            storage,
+		accountConfig:      accountConfig,
+	}
+}
+
+// DeleteAccount only schedules the purge and signs the user out everywhere,
+// logging in again and restoring within the grace period keeps the account.
+func (s *accountService) DeleteAccount(ctx context.Context, userId string, req dto.AccountDeleteRequest) (dto.AccountDeleteResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.AccountDeleteResponse{}, dto.ErrGetUserById
+	}
+
+	if user.PasswordUnset {
+		return dto.AccountDeleteResponse{}, dto.ErrPasswordNotSet
+	}
+
+	checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.Password))
+	if err != nil || !checkPassword {
+		return dto.AccountDeleteResponse{}, dto.ErrPasswordNotMatch
+	}
+
+	if user.DeletionScheduledAt != nil {
+		return dto.AccountDeleteResponse{}, dto.ErrAccountAlreadyDeleted
+	}
+
+	scheduledAt := time.Now().Add(s.accountConfig.DeletionGracePeriod)
+	if err := s.userRepo.UpdateDeletionScheduledAt(ctx, nil, userId, &scheduledAt); err != nil {
+		return dto.AccountDeleteResponse{}, dto.ErrDeleteAccount
+	}
+
+	if err := s.sessionService.LogoutAll(ctx, userId); err != nil {
+		return dto.AccountDeleteResponse{}, err
+	}
+
+	return dto.AccountDeleteResponse{
+		DeletionScheduledAt: scheduledAt,
+	}, nil
+}
+
+func (s *accountService) RestoreAccount(ctx context.Context, userId string) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.DeletionScheduledAt == nil {
+		return dto.ErrAccountNotDeleted
+	}
+
+	if err := s.userRepo.UpdateDeletionScheduledAt(ctx, nil, userId, nil); err != nil {
+		return dto.ErrRestoreAccount
+	}
+
+	return nil
+}
+
+// PurgeDeletedAccounts purges one batch of accounts past their grace period
+// and reports how many were purged.
+func (s *accountService) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	users, err := s.userRepo.GetUsersDueForPurge(ctx, nil, time.Now(), constants.ENUM_ACCOUNT_PURGE_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		files, err := s.postAttachmentRepo.GetAttachmentPathsByUserId(ctx, nil, user.ID.String())
+		if err != nil {
+			return purged, err
+		}
+
+		if err := s.userRepo.PurgeUser(ctx, nil, user.ID.String()); err != nil {
+			return purged, err
+		}
+		purged++
+
+		if user.ImageUrl != nil {
+			files = append(files, utils.ImageVariantKeys(*user.ImageUrl, utils.AvatarVariants)...)
+		}
+		if user.BannerUrl != nil {
+			files = append(files, utils.ImageVariantKeys(*user.BannerUrl, utils.BannerVariants)...)
+		}
+
+		// the account is purged already, a file left behind doesn't undo that
+		// or hold up the rest of the batch
+		for _, file := range files {
+			if err := s.storage.Delete(ctx, file); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+
+	return purged, nil
+}
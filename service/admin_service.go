@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	AdminService interface {
+		GetUsers(ctx context.Context, req dto.PaginationRequest) (dto.AdminUserPaginationResponse, error)
+		SuspendUser(ctx context.Context, adminId string, userId string) error
+		UnsuspendUser(ctx context.Context, userId string) error
+		ForceDeletePost(ctx context.Context, postId uint64) error
+	}
+
+	adminService struct {
+		userRepo           repository.UserRepository
+		postRepo           repository.PostRepository
+		postAttachmentRepo repository.PostAttachmentRepository
+		sessionRepo        repository.SessionRepository
+		storage            utils.Storage
+	}
+)
+
+func NewAdminService(userRepo repository.UserRepository, postRepo repository.PostRepository, postAttachmentRepo repository.PostAttachmentRepository, sessionRepo repository.SessionRepository, storage utils.Storage) AdminService {
+	return &adminService{
+		userRepo:           userRepo,
+		postRepo:           postRepo,
+		postAttachmentRepo: postAttachmentRepo,
+		sessionRepo:        sessionRepo,
+		storage:            storage,
+	}
+}
+
+func (s *adminService) GetUsers(ctx context.Context, req dto.PaginationRequest) (dto.AdminUserPaginationResponse, error) {
+	dataWithPaginate, err := s.userRepo.GetAllUsersWithPagination(ctx, nil, req)
+	if err != nil {
+		return dto.AdminUserPaginationResponse{}, dto.ErrGetUsers
+	}
+
+	data := make([]dto.AdminUserResponse, 0, len(dataWithPaginate.Users))
+	for _, user := range dataWithPaginate.Users {
+		data = append(data, dto.AdminUserResponse{
+			UserResponse: toUserResponse(s.storage, user),
+			Role:         user.Role,
+			SuspendedAt:  user.SuspendedAt,
+			CreatedAt:    user.CreatedAt,
+		})
+	}
+
+	return dto.AdminUserPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// SuspendUser also revokes every session of the user, so existing access
+// tokens stop working right away instead of when they expire.
+func (s *adminService) SuspendUser(ctx context.Context, adminId string, userId string) error {
+	if _, err := uuid.Parse(userId); err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if userId == adminId {
+		return dto.ErrSuspendSelf
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.Role == constants.ENUM_ROLE_ADMIN {
+		return dto.ErrSuspendAdmin
+	}
+
+	if user.SuspendedAt != nil {
+		return dto.ErrAlreadySuspended
+	}
+
+	now := time.Now()
+	if err := s.userRepo.UpdateSuspendedAt(ctx, nil, userId, &now); err != nil {
+		return dto.ErrSuspendUser
+	}
+
+	if err := s.sessionRepo.RevokeSessionsByUserId(ctx, nil, userId); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+func (s *adminService) UnsuspendUser(ctx context.Context, userId string) error {
+	if _, err := uuid.Parse(userId); err != nil {
+		return dto.ErrGetUserById
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.SuspendedAt == nil {
+		return dto.ErrNotSuspended
+	}
+
+	if err := s.userRepo.UpdateSuspendedAt(ctx, nil, userId, nil); err != nil {
+		return dto.ErrSuspendUser
+	}
+
+	return nil
+}
+
+// ForceDeletePost removes any post regardless of its owner, already deleted
+// ones included. Unlike a regular delete nothing of it is kept, its
+// attachments are deleted from the storage once the post is gone.
+func (s *adminService) ForceDeletePost(ctx context.Context, postId uint64) error {
+	if _, err := s.postRepo.GetPostByIdWithDeleted(ctx, nil, postId); err != nil {
+		return dto.ErrGetPostById
+	}
+
+	files, err := s.postAttachmentRepo.GetAttachmentPathsByPostId(ctx, nil, postId)
+	if err != nil {
+		return dto.ErrForceDeletePost
+	}
+
+	if err := s.postRepo.ForceDeletePost(ctx, nil, postId); err != nil {
+		return dto.ErrForceDeletePost
+	}
+
+	for _, file := range files {
+		if err := s.storage.Delete(ctx, file); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
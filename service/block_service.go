@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	BlockService interface {
+		BlockUser(ctx context.Context, userId string, username string) error
+		UnblockUser(ctx context.Context, userId string, username string) error
+		GetBlocks(ctx context.Context, userId string, req dto.PaginationRequest) (dto.UserPaginationResponse, error)
+		MuteUser(ctx context.Context, userId string, username string) error
+		UnmuteUser(ctx context.Context, userId string, username string) error
+		GetMutes(ctx context.Context, userId string, req dto.PaginationRequest) (dto.UserPaginationResponse, error)
+	}
+
+	blockService struct {
+		userRepo     repository.UserRepository
+		blockRepo    repository.BlockRepository
+		muteRepo     repository.MuteRepository
+		followRepo   repository.FollowRepository
+		timelineRepo repository.TimelineRepository
+		jwtService   JWTService
+		storage      utils.Storage
+	}
+)
+
+func NewBlockService(userRepo repository.UserRepository, blockRepo repository.BlockRepository, muteRepo repository.MuteRepository, followRepo repository.FollowRepository, timelineRepo repository.TimelineRepository, jwtService JWTService, storage utils.Storage) BlockService {
+	return &blockService{
+		userRepo:     userRepo,
+		blockRepo:    blockRepo,
+		muteRepo:     muteRepo,
+		followRepo:   followRepo,
+		timelineRepo: timelineRepo,
+		jwtService:   jwtService,
+		storage:      storage,
+	}
+}
+
+// BlockUser also removes the follows between both users, so neither keeps
+// seeing the other in their timeline.
+func (s *blockService) BlockUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	if targetId == userId {
+		return dto.ErrBlockSelf
+	}
+
+	blocked, err := s.blockRepo.CheckBlocked(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrBlockUser
+	}
+
+	if blocked {
+		return dto.ErrAlreadyBlocked
+	}
+
+	if err := s.blockRepo.BlockUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrBlockUser
+	}
+
+	if err := s.removeFollow(ctx, userId, targetId); err != nil {
+		return dto.ErrBlockUser
+	}
+
+	if err := s.removeFollow(ctx, targetId, userId); err != nil {
+		return dto.ErrBlockUser
+	}
+
+	return nil
+}
+
+func (s *blockService) UnblockUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	blocked, err := s.blockRepo.CheckBlocked(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrUnblockUser
+	}
+
+	if !blocked {
+		return dto.ErrNotBlocked
+	}
+
+	if err := s.blockRepo.UnblockUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrUnblockUser
+	}
+
+	return nil
+}
+
+func (s *blockService) GetBlocks(ctx context.Context, userId string, req dto.PaginationRequest) (dto.UserPaginationResponse, error) {
+	dataWithPaginate, err := s.blockRepo.GetBlockedWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrGetBlocks
+	}
+
+	return toUserPaginationResponse(s.storage, dataWithPaginate), nil
+}
+
+// MuteUser hides the user's posts from the viewer, the muted user is not
+// told and can still interact as usual.
+func (s *blockService) MuteUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	if targetId == userId {
+		return dto.ErrMuteSelf
+	}
+
+	muted, err := s.muteRepo.CheckMuted(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrMuteUser
+	}
+
+	if muted {
+		return dto.ErrAlreadyMuted
+	}
+
+	if err := s.muteRepo.MuteUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrMuteUser
+	}
+
+	return nil
+}
+
+func (s *blockService) UnmuteUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	muted, err := s.muteRepo.CheckMuted(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrUnmuteUser
+	}
+
+	if !muted {
+		return dto.ErrNotMuted
+	}
+
+	if err := s.muteRepo.UnmuteUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrUnmuteUser
+	}
+
+	return nil
+}
+
+func (s *blockService) GetMutes(ctx context.Context, userId string, req dto.PaginationRequest) (dto.UserPaginationResponse, error) {
+	dataWithPaginate, err := s.muteRepo.GetMutedWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrGetMutes
+	}
+
+	return toUserPaginationResponse(s.storage, dataWithPaginate), nil
+}
+
+func (s *blockService) removeFollow(ctx context.Context, followerId string, followingId string) error {
+	following, err := s.followRepo.CheckFollowing(ctx, nil, followerId, followingId)
+	if err != nil || !following {
+		return err
+	}
+
+	if err := s.followRepo.UnfollowUser(ctx, nil, followerId, followingId); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateFollowingCount(ctx, nil, followerId, -1); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateFollowersCount(ctx, nil, followingId, -1); err != nil {
+		return err
+	}
+
+	return s.timelineRepo.RemoveAuthorFromTimeline(ctx, nil, followerId, followingId)
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	BookmarkService interface {
+		BookmarkPostById(ctx context.Context, postId uint64, userId string) error
+		UnbookmarkPostById(ctx context.Context, postId uint64, userId string) error
+		GetBookmarks(ctx context.Context, userId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error)
+	}
+
+	bookmarkService struct {
+		bookmarkRepo repository.BookmarkRepository
+		postRepo     repository.PostRepository
+		pollRepo     repository.PollRepository
+		blockRepo    repository.BlockRepository
+		jwtService   JWTService
+		storage      utils.Storage
+	}
+)
+
+func NewBookmarkService(bookmarkRepo repository.BookmarkRepository, postRepo repository.PostRepository, pollRepo repository.PollRepository, blockRepo repository.BlockRepository, jwtService JWTService, storage utils.Storage) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		postRepo:     postRepo,
+		pollRepo:     pollRepo,
+		blockRepo:    blockRepo,
+		jwtService:   jwtService,
+		storage:      storage,
+	}
+}
+
+func (s *bookmarkService) BookmarkPostById(ctx context.Context, postId uint64, userId string) error {
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
+	if err != nil {
+		return dto.ErrGetPostById
+	}
+
+	post, ok := originalPost(post)
+	if !ok {
+		return dto.ErrGetPostById
+	}
+	postId = post.ID
+
+	if err := s.bookmarkRepo.CheckBookmarkedPost(ctx, nil, postId, userId); err == nil {
+		return dto.ErrAlreadyBookmarked
+	}
+
+	if err := s.bookmarkRepo.BookmarkPostById(ctx, nil, postId, userId); err != nil {
+		return dto.ErrBookmarkPostById
+	}
+
+	return nil
+}
+
+func (s *bookmarkService) UnbookmarkPostById(ctx context.Context, postId uint64, userId string) error {
+	postId = originalPostId(ctx, s.postRepo, postId)
+
+	if err := s.bookmarkRepo.CheckBookmarkedPost(ctx, nil, postId, userId); err != nil {
+		return dto.ErrCheckBookmarkedPost
+	}
+
+	if err := s.bookmarkRepo.UnbookmarkPostById(ctx, nil, postId, userId); err != nil {
+		return dto.ErrUnbookmarkPostById
+	}
+
+	return nil
+}
+
+func (s *bookmarkService) GetBookmarks(ctx context.Context, userId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error) {
+	dataWithPaginate, err := s.bookmarkRepo.GetBookmarksWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetBookmarks
+	}
+
+	data := make([]dto.PostResponse, 0, len(dataWithPaginate.Posts))
+	for _, post := range dataWithPaginate.Posts {
+		datum := toPostResponse(s.storage, post)
+		datum.IsBookmarked = true
+
+		data = append(data, datum)
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, userId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetBookmarks
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, userId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetBookmarks
+	}
+
+	return dto.PostPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
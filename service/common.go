@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+// toUserResponse turns the stored image keys into signed links, links are
+// only made while building a response because they expire.
+func toUserResponse(storage utils.Storage, user entity.User) dto.UserResponse {
+	imageUrls := imageURLs(storage, user.ImageUrl, utils.AvatarVariants)
+
+	var imageUrl *string
+	if imageUrls != nil {
+		url := imageUrls[utils.AvatarVariants[len(utils.AvatarVariants)-1].Name()]
+		imageUrl = &url
+	}
+
+	return dto.UserResponse{
+		ID:             user.ID.String(),
+		Name:           user.Name,
+		UserName:       user.Username,
+		Bio:            user.Bio,
+		ImageUrl:       imageUrl,
+		TotalFollowers: user.TotalFollowers,
+		TotalFollowing: user.TotalFollowing,
+		IsVerified:     user.EmailVerifiedAt != nil,
+		ImageUrls:      imageUrls,
+		BannerUrls:     imageURLs(storage, user.BannerUrl, utils.BannerVariants),
+	}
+}
+
+func imageURLs(storage utils.Storage, key *string, variants []utils.ImageVariant) map[string]string {
+	if key == nil {
+		return nil
+	}
+
+	urls := make(map[string]string, len(variants))
+	for _, variant := range variants {
+		urls[variant.Name()] = storage.URL(utils.ImageVariantKey(*key, variant))
+	}
+
+	return urls
+}
+
+func toUserPaginationResponse(storage utils.Storage, dataWithPaginate dto.GetAllUsersRepositoryResponse) dto.UserPaginationResponse {
+	data := make([]dto.UserResponse, 0, len(dataWithPaginate.Users))
+	for _, user := range dataWithPaginate.Users {
+		data = append(data, toUserResponse(storage, user))
+	}
+
+	return dto.UserPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}
+}
+
+// toPostResponse renders a post, a repost is rendered as the original
+// post with the reposting user attached.
+func toPostResponse(storage utils.Storage, post entity.Post) dto.PostResponse {
+	if post.RepostOf != nil {
+		reposter := toUserResponse(storage, post.User)
+		response := toPostResponse(storage, *post.RepostOf)
+		response.RepostedBy = &reposter
+		return response
+	}
+
+	response := dto.PostResponse{
+		ID:           post.ID,
+		Text:         post.Text,
+		TotalLikes:   post.TotalLikes,
+		TotalReposts: post.TotalReposts,
+		IsDeleted:    post.DeletedAt.Valid,
+		IsEdited:     post.EditedAt != nil,
+		EditedAt:     post.EditedAt,
+		ParentID:     post.ParentID,
+		QuotedPostID: post.QuotedPostID,
+		User:         toUserResponse(storage, post.User),
+		Mentions:     make([]dto.MentionResponse, 0, len(post.Mentions)),
+		Attachments:  make([]dto.AttachmentResponse, 0, len(post.Attachments)),
+	}
+
+	for _, mention := range post.Mentions {
+		response.Mentions = append(response.Mentions, dto.MentionResponse{
+			UserID:   mention.UserID.String(),
+			UserName: mention.User.Username,
+			Start:    mention.StartIndex,
+			End:      mention.EndIndex,
+		})
+	}
+
+	for _, attachment := range post.Attachments {
+		response.Attachments = append(response.Attachments, dto.AttachmentResponse{
+			ID:       attachment.ID.String(),
+			Type:     attachment.Type,
+			MimeType: attachment.MimeType,
+			URL:      storage.URL(attachment.Path),
+			Width:    attachment.Width,
+			Height:   attachment.Height,
+			AltText:  attachment.AltText,
+		})
+	}
+
+	if post.Poll != nil {
+		poll := toPollResponse(*post.Poll, false)
+		response.Poll = &poll
+	}
+
+	if post.QuotedPost != nil {
+		quoted := toQuotedPostResponse(storage, *post.QuotedPost)
+		response.QuotedPost = &quoted
+	}
+
+	return response
+}
+
+// originalPost resolves a repost to the post it shares, every interaction
+// through a repost lands on the original. It reports false when the original
+// was deleted.
+func originalPost(post entity.Post) (entity.Post, bool) {
+	if post.RepostOf == nil {
+		return post, true
+	}
+
+	if post.RepostOf.DeletedAt.Valid {
+		return entity.Post{}, false
+	}
+
+	return *post.RepostOf, true
+}
+
+// originalPostId resolves a repost id when an interaction is undone, which
+// still works after the post or its original was deleted.
+func originalPostId(ctx context.Context, postRepo repository.PostRepository, postId uint64) uint64 {
+	post, err := postRepo.GetPostByIdWithDeleted(ctx, nil, postId)
+	if err == nil && post.RepostOfID != nil {
+		return *post.RepostOfID
+	}
+
+	return postId
+}
+
+// toPollResponse leaves out the counts of open polls unless reveal is set,
+// markPollVotes reveals them to viewers who voted.
+func toPollResponse(poll entity.Poll, reveal bool) dto.PollResponse {
+	closed := !poll.ClosesAt.After(time.Now())
+	reveal = reveal || closed
+
+	response := dto.PollResponse{
+		ID:       poll.ID.String(),
+		Options:  make([]dto.PollOptionResponse, 0, len(poll.Options)),
+		ClosesAt: poll.ClosesAt,
+		IsClosed: closed,
+	}
+
+	if reveal {
+		totalVotes := poll.TotalVotes
+		response.TotalVotes = &totalVotes
+	}
+
+	for _, option := range poll.Options {
+		optionResponse := dto.PollOptionResponse{
+			ID:   option.ID.String(),
+			Text: option.Text,
+		}
+
+		if reveal {
+			votes := option.TotalVotes
+			optionResponse.Votes = &votes
+		}
+
+		response.Options = append(response.Options, optionResponse)
+	}
+
+	return response
+}
+
+// toQuotedPostResponse renders a quoted post one level deep, a deleted
+// quoted post only keeps its id as a tombstone.
+func toQuotedPostResponse(storage utils.Storage, post entity.Post) dto.PostResponse {
+	if post.DeletedAt.Valid {
+		return dto.PostResponse{
+			ID:        post.ID,
+			IsDeleted: true,
+		}
+	}
+
+	post.QuotedPost = nil
+	return toPostResponse(storage, post)
+}
+
+// hideQuotedPosts turns quoted posts by users the viewer blocked, was
+// blocked by or muted into tombstones like deleted ones.
+func hideQuotedPosts(ctx context.Context, blockRepo repository.BlockRepository, viewerId string, posts []dto.PostResponse) error {
+	if viewerId == "" {
+		return nil
+	}
+
+	var authorIds []string
+	for _, post := range posts {
+		if post.QuotedPost != nil && !post.QuotedPost.IsDeleted {
+			authorIds = append(authorIds, post.QuotedPost.User.ID)
+		}
+	}
+
+	if len(authorIds) == 0 {
+		return nil
+	}
+
+	hiddenIds, err := blockRepo.GetHiddenUserIds(ctx, nil, viewerId, authorIds)
+	if err != nil {
+		return err
+	}
+
+	hidden := make(map[string]bool, len(hiddenIds))
+	for _, id := range hiddenIds {
+		hidden[id] = true
+	}
+
+	for i := range posts {
+		if quoted := posts[i].QuotedPost; quoted != nil && hidden[quoted.User.ID] {
+			posts[i].QuotedPost = &dto.PostResponse{
+				ID:       quoted.ID,
+				IsHidden: true,
+			}
+		}
+	}
+
+	return nil
+}
+
+// markBookmarked flags the posts the viewer has bookmarked, posts are
+// left untouched for anonymous viewers.
+func markBookmarked(ctx context.Context, bookmarkRepo repository.BookmarkRepository, viewerId string, posts []dto.PostResponse) error {
+	if viewerId == "" || len(posts) == 0 {
+		return nil
+	}
+
+	postIds := make([]uint64, 0, len(posts))
+	for _, post := range posts {
+		postIds = append(postIds, post.ID)
+	}
+
+	bookmarkedIds, err := bookmarkRepo.GetBookmarkedPostIds(ctx, nil, viewerId, postIds)
+	if err != nil {
+		return err
+	}
+
+	bookmarked := make(map[uint64]bool, len(bookmarkedIds))
+	for _, id := range bookmarkedIds {
+		bookmarked[id] = true
+	}
+
+	for i := range posts {
+		posts[i].IsBookmarked = bookmarked[posts[i].ID]
+	}
+
+	return nil
+}
+
+// markPollVotes sets the option the viewer voted for on the polls of the
+// posts and quoted posts, and reveals the counts of those polls.
+func markPollVotes(ctx context.Context, pollRepo repository.PollRepository, viewerId string, posts []dto.PostResponse) error {
+	if viewerId == "" {
+		return nil
+	}
+
+	polls := map[string][]*dto.PollResponse{}
+	for i := range posts {
+		for _, post := range []*dto.PostResponse{&posts[i], posts[i].QuotedPost} {
+			if post != nil && post.Poll != nil {
+				polls[post.Poll.ID] = append(polls[post.Poll.ID], post.Poll)
+			}
+		}
+	}
+
+	if len(polls) == 0 {
+		return nil
+	}
+
+	pollIds := make([]string, 0, len(polls))
+	for id := range polls {
+		pollIds = append(pollIds, id)
+	}
+
+	votes, err := pollRepo.GetVotesByUserId(ctx, nil, viewerId, pollIds)
+	if err != nil || len(votes) == 0 {
+		return err
+	}
+
+	voted := make(map[string]string, len(votes))
+	votedPollIds := make([]string, 0, len(votes))
+	for _, vote := range votes {
+		voted[vote.PollID.String()] = vote.OptionID.String()
+		votedPollIds = append(votedPollIds, vote.PollID.String())
+	}
+
+	results, err := pollRepo.GetPollsByIds(ctx, nil, votedPollIds)
+	if err != nil {
+		return err
+	}
+
+	for _, poll := range results {
+		optionId := voted[poll.ID.String()]
+		for _, response := range polls[poll.ID.String()] {
+			*response = toPollResponse(poll, true)
+			response.VotedOptionID = &optionId
+		}
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a wrong password or two factor code. The account
+// is locked once the threshold is reached, every further failure doubles
+// the lock up to the configured maximum.
+func recordFailedLogin(ctx context.Context, userRepo repository.UserRepository, lockoutConfig config.LoginLockoutConfig, userId string) {
+	attempts, err := userRepo.RecordFailedLogin(ctx, nil, userId)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	over := attempts - lockoutConfig.Threshold
+	if over < 0 {
+		return
+	}
+
+	duration := lockoutConfig.Duration
+	for i := 0; i < over && duration < lockoutConfig.MaxDuration; i++ {
+		duration *= 2
+	}
+	duration = min(duration, lockoutConfig.MaxDuration)
+
+	if err := userRepo.UpdateLockedUntil(ctx, nil, userId, time.Now().Add(duration)); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	ConversationService interface {
+		CreateConversation(ctx context.Context, userId string, req dto.ConversationCreateRequest) (dto.ConversationResponse, error)
+		GetConversations(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ConversationPaginationResponse, error)
+		GetConversationById(ctx context.Context, userId string, conversationId uint64) (dto.ConversationResponse, error)
+		SendMessage(ctx context.Context, userId string, conversationId uint64, req dto.MessageCreateRequest) (dto.MessageResponse, error)
+		GetMessages(ctx context.Context, userId string, conversationId uint64, req dto.MessageCursorRequest) (dto.MessagePaginationResponse, error)
+		MarkAsRead(ctx context.Context, userId string, conversationId uint64, req dto.ConversationReadRequest) error
+	}
+
+	conversationService struct {
+		userRepo         repository.UserRepository
+		conversationRepo repository.ConversationRepository
+		messageRepo      repository.MessageRepository
+		blockRepo        repository.BlockRepository
+		jwtService       JWTService
+		storage          utils.Storage
+	}
+)
+
+func NewConversationService(userRepo repository.UserRepository, conversationRepo repository.ConversationRepository, messageRepo repository.MessageRepository, blockRepo repository.BlockRepository, jwtService JWTService, storage utils.Storage) ConversationService {
+	return &conversationService{
+		userRepo:         userRepo,
+		conversationRepo: conversationRepo,
+		messageRepo:      messageRepo,
+		blockRepo:        blockRepo,
+		jwtService:       jwtService,
+		storage:          storage,
+	}
+}
+
+// CreateConversation starts a conversation with the given users, a one to one
+// conversation that already exists is returned instead of a new one. Users
+// who blocked or were blocked by the creator can't be added.
+func (s *conversationService) CreateConversation(ctx context.Context, userId string, req dto.ConversationCreateRequest) (dto.ConversationResponse, error) {
+	participants := []entity.ConversationParticipant{{UserID: uuid.MustParse(userId)}}
+	seen := map[string]bool{userId: true}
+	for _, username := range req.Usernames {
+		user, flag, err := s.userRepo.CheckUsername(ctx, nil, username)
+		if err != nil || !flag {
+			return dto.ConversationResponse{}, dto.ErrUsernameNotFound
+		}
+
+		id := user.ID.String()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, id)
+		if err != nil {
+			return dto.ConversationResponse{}, dto.ErrCheckBlocked
+		}
+
+		if blocked {
+			return dto.ConversationResponse{}, dto.ErrBlocked
+		}
+
+		participants = append(participants, entity.ConversationParticipant{UserID: user.ID})
+	}
+
+	if len(participants) < 2 {
+		return dto.ConversationResponse{}, dto.ErrConversationSelf
+	}
+
+	if len(participants) > constants.ENUM_CONVERSATION_MAX_PARTICIPANTS {
+		return dto.ConversationResponse{}, dto.ErrTooManyParticipants
+	}
+
+	isGroup := len(participants) > 2
+	if !isGroup {
+		existing, err := s.conversationRepo.GetDirectConversation(ctx, nil, userId, participants[1].UserID.String())
+		if err == nil {
+			return s.toConversationResponse(ctx, userId, existing)
+		}
+	}
+
+	conversation := entity.Conversation{
+		IsGroup:       isGroup,
+		CreatedByID:   uuid.MustParse(userId),
+		LastMessageAt: time.Now(),
+		Participants:  participants,
+	}
+
+	if isGroup {
+		conversation.Name = req.Name
+	}
+
+	created, err := s.conversationRepo.CreateConversation(ctx, nil, conversation)
+	if err != nil {
+		return dto.ConversationResponse{}, dto.ErrCreateConversation
+	}
+
+	result, err := s.conversationRepo.GetConversationById(ctx, nil, created.ID)
+	if err != nil {
+		return dto.ConversationResponse{}, dto.ErrGetConversation
+	}
+
+	return s.toConversationResponse(ctx, userId, result)
+}
+
+func (s *conversationService) GetConversations(ctx context.Context, userId string, req dto.PaginationRequest) (dto.ConversationPaginationResponse, error) {
+	dataWithPaginate, err := s.conversationRepo.GetConversationsWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.ConversationPaginationResponse{}, dto.ErrGetConversations
+	}
+
+	conversationIds := make([]uint64, 0, len(dataWithPaginate.Conversations))
+	for _, conversation := range dataWithPaginate.Conversations {
+		conversationIds = append(conversationIds, conversation.ID)
+	}
+
+	unread, err := s.conversationRepo.CountUnreadMessages(ctx, nil, userId, conversationIds)
+	if err != nil {
+		return dto.ConversationPaginationResponse{}, dto.ErrGetConversations
+	}
+
+	data := make([]dto.ConversationResponse, 0, len(dataWithPaginate.Conversations))
+	for _, conversation := range dataWithPaginate.Conversations {
+		response := toConversationResponse(s.storage, conversation)
+		response.UnreadCount = unread[conversation.ID]
+		data = append(data, response)
+	}
+
+	return dto.ConversationPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+func (s *conversationService) GetConversationById(ctx context.Context, userId string, conversationId uint64) (dto.ConversationResponse, error) {
+	conversation, err := s.conversationRepo.GetConversationById(ctx, nil, conversationId)
+	if err != nil {
+		return dto.ConversationResponse{}, dto.ErrGetConversation
+	}
+
+	if !isParticipant(conversation, userId) {
+		return dto.ConversationResponse{}, dto.ErrNotParticipant
+	}
+
+	return s.toConversationResponse(ctx, userId, conversation)
+}
+
+// SendMessage posts to a conversation of the user, one to one conversations
+// close once either side blocked the other.
+func (s *conversationService) SendMessage(ctx context.Context, userId string, conversationId uint64, req dto.MessageCreateRequest) (dto.MessageResponse, error) {
+	conversation, err := s.conversationRepo.GetConversationById(ctx, nil, conversationId)
+	if err != nil {
+		return dto.MessageResponse{}, dto.ErrGetConversation
+	}
+
+	if !isParticipant(conversation, userId) {
+		return dto.MessageResponse{}, dto.ErrNotParticipant
+	}
+
+	if !conversation.IsGroup {
+		for _, participant := range conversation.Participants {
+			if participant.UserID.String() == userId {
+				continue
+			}
+
+			blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, participant.UserID.String())
+			if err != nil {
+				return dto.MessageResponse{}, dto.ErrCheckBlocked
+			}
+
+			if blocked {
+				return dto.MessageResponse{}, dto.ErrBlocked
+			}
+		}
+	}
+
+	message := entity.Message{
+		ConversationID: conversationId,
+		Text:           req.Text,
+		SenderID:       uuid.MustParse(userId),
+	}
+
+	result, err := s.messageRepo.CreateMessage(ctx, nil, message)
+	if err != nil {
+		return dto.MessageResponse{}, dto.ErrSendMessage
+	}
+
+	if err := s.conversationRepo.UpdateLastMessage(ctx, nil, result); err != nil {
+		return dto.MessageResponse{}, dto.ErrSendMessage
+	}
+
+	// the sender has read everything up to their own message
+	if err := s.conversationRepo.UpdateReadReceipt(ctx, nil, conversationId, userId, result.ID); err != nil {
+		return dto.MessageResponse{}, dto.ErrSendMessage
+	}
+
+	sender, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.MessageResponse{}, dto.ErrGetUserById
+	}
+
+	result.Sender = sender
+	return toMessageResponse(s.storage, result), nil
+}
+
+func (s *conversationService) GetMessages(ctx context.Context, userId string, conversationId uint64, req dto.MessageCursorRequest) (dto.MessagePaginationResponse, error) {
+	if err := s.checkParticipant(ctx, userId, conversationId); err != nil {
+		return dto.MessagePaginationResponse{}, err
+	}
+
+	messages, hasMore, err := s.messageRepo.GetMessagesWithCursor(ctx, nil, conversationId, req)
+	if err != nil {
+		return dto.MessagePaginationResponse{}, dto.ErrGetMessages
+	}
+
+	data := make([]dto.MessageResponse, 0, len(messages))
+	for _, message := range messages {
+		data = append(data, toMessageResponse(s.storage, message))
+	}
+
+	var nextCursor *uint64
+	if hasMore {
+		nextCursor = &messages[len(messages)-1].ID
+	}
+
+	return dto.MessagePaginationResponse{
+		Data: data,
+		MessageCursorResponse: dto.MessageCursorResponse{
+			NextCursor: nextCursor,
+			HasMore:    hasMore,
+		},
+	}, nil
+}
+
+func (s *conversationService) MarkAsRead(ctx context.Context, userId string, conversationId uint64, req dto.ConversationReadRequest) error {
+	if err := s.checkParticipant(ctx, userId, conversationId); err != nil {
+		return err
+	}
+
+	var message entity.Message
+	var err error
+	if req.MessageID != nil {
+		message, err = s.messageRepo.GetMessageById(ctx, nil, conversationId, *req.MessageID)
+	} else {
+		message, err = s.messageRepo.GetLatestMessage(ctx, nil, conversationId)
+	}
+
+	if err != nil {
+		return dto.ErrGetMessageById
+	}
+
+	if err := s.conversationRepo.UpdateReadReceipt(ctx, nil, conversationId, userId, message.ID); err != nil {
+		return dto.ErrReadConversation
+	}
+
+	return nil
+}
+
+func (s *conversationService) checkParticipant(ctx context.Context, userId string, conversationId uint64) error {
+	flag, err := s.conversationRepo.CheckParticipant(ctx, nil, conversationId, userId)
+	if err != nil {
+		return dto.ErrGetConversation
+	}
+
+	if !flag {
+		return dto.ErrNotParticipant
+	}
+
+	return nil
+}
+
+func (s *conversationService) toConversationResponse(ctx context.Context, userId string, conversation entity.Conversation) (dto.ConversationResponse, error) {
+	unread, err := s.conversationRepo.CountUnreadMessages(ctx, nil, userId, []uint64{conversation.ID})
+	if err != nil {
+		return dto.ConversationResponse{}, dto.ErrGetConversation
+	}
+
+	response := toConversationResponse(s.storage, conversation)
+	response.UnreadCount = unread[conversation.ID]
+	return response, nil
+}
+
+func isParticipant(conversation entity.Conversation, userId string) bool {
+	for _, participant := range conversation.Participants {
+		if participant.UserID.String() == userId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toConversationResponse(storage utils.Storage, conversation entity.Conversation) dto.ConversationResponse {
+	response := dto.ConversationResponse{
+		ID:            conversation.ID,
+		Name:          conversation.Name,
+		IsGroup:       conversation.IsGroup,
+		Participants:  make([]dto.ParticipantResponse, 0, len(conversation.Participants)),
+		LastMessageAt: conversation.LastMessageAt,
+	}
+
+	for _, participant := range conversation.Participants {
+		response.Participants = append(response.Participants, dto.ParticipantResponse{
+			User:              toUserResponse(storage, participant.User),
+			LastReadMessageID: participant.LastReadMessageID,
+			LastReadAt:        participant.LastReadAt,
+		})
+	}
+
+	if conversation.LastMessage != nil {
+		lastMessage := toMessageResponse(storage, *conversation.LastMessage)
+		response.LastMessage = &lastMessage
+	}
+
+	return response
+}
+
+func toMessageResponse(storage utils.Storage, message entity.Message) dto.MessageResponse {
+	return dto.MessageResponse{
+		ID:             message.ID,
+		ConversationID: message.ConversationID,
+		Text:           message.Text,
+		Sender:         toUserResponse(storage, message.Sender),
+		CreatedAt:      message.CreatedAt,
+	}
+}
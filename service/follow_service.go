@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	FollowService interface {
+		FollowUser(ctx context.Context, userId string, username string) error
+		UnfollowUser(ctx context.Context, userId string, username string) error
+		GetFollowers(ctx context.Context, username string, req dto.PaginationRequest) (dto.UserPaginationResponse, error)
+		GetFollowing(ctx context.Context, username string, req dto.PaginationRequest) (dto.UserPaginationResponse, error)
+	}
+
+	followService struct {
+		userRepo            repository.UserRepository
+		followRepo          repository.FollowRepository
+		timelineRepo        repository.TimelineRepository
+		blockRepo           repository.BlockRepository
+		notificationService NotificationService
+		jwtService          JWTService
+		storage             utils.Storage
+	}
+)
+
+func NewFollowService(userRepo repository.UserRepository, followRepo repository.FollowRepository, timelineRepo repository.TimelineRepository, blockRepo repository.BlockRepository, notificationService NotificationService, jwtService JWTService, storage utils.Storage) FollowService {
+	return &followService{
+		userRepo:            userRepo,
+		followRepo:          followRepo,
+		timelineRepo:        timelineRepo,
+		blockRepo:           blockRepo,
+		notificationService: notificationService,
+		jwtService:          jwtService,
+		storage:             storage,
+	}
+}
+
+func (s *followService) FollowUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	if targetId == userId {
+		return dto.ErrFollowSelf
+	}
+
+	blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrCheckBlocked
+	}
+
+	if blocked {
+		return dto.ErrBlocked
+	}
+
+	following, err := s.followRepo.CheckFollowing(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrFollowUser
+	}
+
+	if following {
+		return dto.ErrAlreadyFollowing
+	}
+
+	if err := s.followRepo.FollowUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrFollowUser
+	}
+
+	if err := s.userRepo.UpdateFollowingCount(ctx, nil, userId, 1); err != nil {
+		return dto.ErrFollowUser
+	}
+
+	if err := s.userRepo.UpdateFollowersCount(ctx, nil, targetId, 1); err != nil {
+		return dto.ErrFollowUser
+	}
+
+	if err := s.timelineRepo.BackfillTimeline(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrFollowUser
+	}
+
+	if err := s.notificationService.Notify(ctx, constants.ENUM_NOTIFICATION_FOLLOW, userId, targetId, nil); err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (s *followService) UnfollowUser(ctx context.Context, userId string, username string) error {
+	target, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.ErrUsernameNotFound
+	}
+
+	targetId := target.ID.String()
+	following, err := s.followRepo.CheckFollowing(ctx, nil, userId, targetId)
+	if err != nil {
+		return dto.ErrUnfollowUser
+	}
+
+	if !following {
+		return dto.ErrNotFollowing
+	}
+
+	if err := s.followRepo.UnfollowUser(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrUnfollowUser
+	}
+
+	if err := s.userRepo.UpdateFollowingCount(ctx, nil, userId, -1); err != nil {
+		return dto.ErrUnfollowUser
+	}
+
+	if err := s.userRepo.UpdateFollowersCount(ctx, nil, targetId, -1); err != nil {
+		return dto.ErrUnfollowUser
+	}
+
+	if err := s.timelineRepo.RemoveAuthorFromTimeline(ctx, nil, userId, targetId); err != nil {
+		return dto.ErrUnfollowUser
+	}
+
+	if err := s.notificationService.Retract(ctx, constants.ENUM_NOTIFICATION_FOLLOW, userId, targetId, nil); err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (s *followService) GetFollowers(ctx context.Context, username string, req dto.PaginationRequest) (dto.UserPaginationResponse, error) {
+	user, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrUsernameNotFound
+	}
+
+	dataWithPaginate, err := s.followRepo.GetFollowersWithPagination(ctx, nil, user.ID.String(), req)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrGetFollowers
+	}
+
+	return toUserPaginationResponse(s.storage, dataWithPaginate), nil
+}
+
+func (s *followService) GetFollowing(ctx context.Context, username string, req dto.PaginationRequest) (dto.UserPaginationResponse, error) {
+	user, _, err := s.userRepo.CheckUsername(ctx, nil, username)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrUsernameNotFound
+	}
+
+	dataWithPaginate, err := s.followRepo.GetFollowingWithPagination(ctx, nil, user.ID.String(), req)
+	if err != nil {
+		return dto.UserPaginationResponse{}, dto.ErrGetFollowing
+	}
+
+	return toUserPaginationResponse(s.storage, dataWithPaginate), nil
+}
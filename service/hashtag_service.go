@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	HashtagService interface {
+		GetPostsByHashtag(ctx context.Context, viewerId string, tag string, req dto.PaginationRequest) (dto.PostPaginationResponse, error)
+		GetTrends(ctx context.Context, req dto.TrendsRequest) ([]dto.TrendResponse, error)
+	}
+
+	hashtagService struct {
+		hashtagRepo  repository.HashtagRepository
+		bookmarkRepo repository.BookmarkRepository
+		pollRepo     repository.PollRepository
+		blockRepo    repository.BlockRepository
+		trendsConfig config.TrendsConfig
+		storage      utils.Storage
+	}
+)
+
+func NewHashtagService(hashtagRepo repository.HashtagRepository, bookmarkRepo repository.BookmarkRepository, pollRepo repository.PollRepository, blockRepo repository.BlockRepository, trendsConfig config.TrendsConfig, storage utils.Storage) HashtagService {
+	return &hashtagService{
+		hashtagRepo:  hashtagRepo,
+		bookmarkRepo: bookmarkRepo,
+		pollRepo:     pollRepo,
+		blockRepo:    blockRepo,
+		trendsConfig: trendsConfig,
+		storage:      storage,
+	}
+}
+
+func (s *hashtagService) GetPostsByHashtag(ctx context.Context, viewerId string, tag string, req dto.PaginationRequest) (dto.PostPaginationResponse, error) {
+	dataWithPaginate, err := s.hashtagRepo.GetPostsByHashtagWithPagination(ctx, nil, viewerId, normalizeHashtag(tag), req)
+	if err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetHashtagPosts
+	}
+
+	data := make([]dto.PostResponse, 0, len(dataWithPaginate.Posts))
+	for _, post := range dataWithPaginate.Posts {
+		data = append(data, toPostResponse(s.storage, post))
+	}
+
+	if err := markBookmarked(ctx, s.bookmarkRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetHashtagPosts
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetHashtagPosts
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetHashtagPosts
+	}
+
+	return dto.PostPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+func (s *hashtagService) GetTrends(ctx context.Context, req dto.TrendsRequest) ([]dto.TrendResponse, error) {
+	if req.Limit <= 0 || req.Limit > 50 {
+		req.Limit = constants.ENUM_TRENDS_LIMIT
+	}
+
+	since := time.Now().Add(-s.trendsConfig.Window)
+	trends, err := s.hashtagRepo.GetTrendingHashtags(ctx, nil, since, s.trendsConfig.HalfLife, req.Limit)
+	if err != nil {
+		return nil, dto.ErrGetTrends
+	}
+
+	return trends, nil
+}
+
+// normalizeHashtag stores and looks up tags case insensitively
+func normalizeHashtag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(tag, "#"))
+}
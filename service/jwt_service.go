@@ -1,76 +1,192 @@
 package service
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
 	"fmt"
 	"log"
-	"os"
+	"math/big"
 	"time"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 type JWTService interface {
-	GenerateToken(userId string) string
+	GenerateToken(userId string, sessionId string, role string) (string, error)
 	ValidateToken(token string) (*jwt.Token, error)
 	GetUserIDByToken(token string) (string, error)
+	GetSessionIDByToken(token string) (string, error)
+	GetRoleByToken(token string) (string, error)
+	GetJWKS() dto.JWKSResponse
+	ValidatePersonalAccessToken(token string) (entity.PersonalAccessToken, error)
 }
 
 type jwtCustomClaim struct {
-	UserID string `json:"user_id"`
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	Role      string `json:"role"`
 	jwt.RegisteredClaims
 }
 
+// how stale last_used_at of a personal access token may get before it is updated
+const personalAccessTokenTouchInterval = time.Minute
+
 type jwtService struct {
-	secretKey string
-	issuer    string
+	keys                    []config.JWTKey
+	issuer                  string
+	expiresIn               time.Duration
+	sessionRepo             repository.SessionRepository
+	personalAccessTokenRepo repository.PersonalAccessTokenRepository
 }
 
-func NewJWTService() JWTService {
+func NewJWTService(sessionRepo repository.SessionRepository, personalAccessTokenRepo repository.PersonalAccessTokenRepository, jwtConfig config.JWTConfig, expiresIn time.Duration) JWTService {
 	return &jwtService{
-		secretKey: getSecretKey(),
-		issuer:    "Template",
+		keys:                    jwtConfig.Keys,
+		issuer:                  jwtConfig.Issuer,
+		expiresIn:               expiresIn,
+		sessionRepo:             sessionRepo,
+		personalAccessTokenRepo: personalAccessTokenRepo,
+	}
+}
+
+// signingKey picks the most recently activated key, keys scheduled for later
+// are only published so verifiers can fetch them ahead of the rotation.
+func (j *jwtService) signingKey() (config.JWTKey, bool) {
+	var current config.JWTKey
+	found := false
+	now := time.Now()
+	for _, key := range j.keys {
+		if key.ActivatesAt.After(now) {
+			continue
+		}
+
+		if !found || key.ActivatesAt.After(current.ActivatesAt) {
+			current = key
+			found = true
+		}
 	}
+
+	return current, found
 }
 
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "Template"
+func signingMethod(algorithm string) jwt.SigningMethod {
+	if algorithm == constants.ENUM_JWT_ALG_EDDSA {
+		return jwt.SigningMethodEdDSA
 	}
-	return secretKey
+	return jwt.SigningMethodRS256
 }
 
-func (j *jwtService) GenerateToken(userId string) string {
+func (j *jwtService) GenerateToken(userId string, sessionId string, role string) (string, error) {
 	claims := jwtCustomClaim{
 		userId,
+		sessionId,
+		role,
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 120)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.expiresIn)),
 			Issuer:    j.issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tx, err := token.SignedString([]byte(j.secretKey))
-	if err != nil {
-		log.Println(err)
+	key, ok := j.signingKey()
+	if !ok {
+		return "", dto.ErrNoActiveSigningKey
 	}
-	return tx
+
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 func (j *jwtService) parseToken(t_ *jwt.Token) (any, error) {
-	if _, ok := t_.Method.(*jwt.SigningMethodHMAC); !ok {
-		return nil, fmt.Errorf("unexpected signing method %v", t_.Header["alg"])
+	kid, _ := t_.Header["kid"].(string)
+	for _, key := range j.keys {
+		if key.ID != kid {
+			continue
+		}
+
+		if t_.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("unexpected signing method %v", t_.Header["alg"])
+		}
+
+		if claims, ok := t_.Claims.(jwt.MapClaims); !ok || !claims.VerifyIssuer(j.issuer, true) {
+			return nil, dto.ErrInvalidIssuer
+		}
+
+		return key.PrivateKey.Public(), nil
 	}
-	return []byte(j.secretKey), nil
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
 }
 
+// GetJWKS publishes the public half of every configured key, including ones
+// not yet used for signing.
+func (j *jwtService) GetJWKS() dto.JWKSResponse {
+	res := dto.JWKSResponse{
+		Keys: []dto.JWK{},
+	}
+
+	for _, key := range j.keys {
+		jwk := dto.JWK{
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			Use:       "sig",
+		}
+
+		switch public := key.PrivateKey.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+
+		res.Keys = append(res.Keys, jwk)
+	}
+
+	return res
+}
+
+// ValidateToken also rejects tokens whose session was revoked or expired
 func (j *jwtService) ValidateToken(token string) (*jwt.Token, error) {
-	return jwt.Parse(token, j.parseToken)
+	t_Token, err := jwt.Parse(token, j.parseToken)
+	if err != nil {
+		return t_Token, err
+	}
+
+	sessionId, err := j.GetSessionIDByToken(token)
+	if err != nil {
+		return t_Token, err
+	}
+
+	active, err := j.sessionRepo.CheckActiveSession(context.Background(), nil, sessionId)
+	if err != nil {
+		return t_Token, err
+	}
+
+	if !active {
+		return t_Token, dto.ErrSessionRevoked
+	}
+
+	return t_Token, nil
 }
 
 func (j *jwtService) GetUserIDByToken(token string) (string, error) {
-	t_Token, err := j.ValidateToken(token)
+	t_Token, err := jwt.Parse(token, j.parseToken)
 	if err != nil {
 		return "", err
 	}
@@ -79,3 +195,58 @@ func (j *jwtService) GetUserIDByToken(token string) (string, error) {
 	id := fmt.Sprintf("%v", claims["user_id"])
 	return id, nil
 }
+
+func (j *jwtService) GetSessionIDByToken(token string) (string, error) {
+	t_Token, err := jwt.Parse(token, j.parseToken)
+	if err != nil {
+		return "", err
+	}
+
+	claims := t_Token.Claims.(jwt.MapClaims)
+	sessionId, ok := claims["session_id"].(string)
+	if !ok || sessionId == "" {
+		return "", dto.ErrSessionNotFound
+	}
+
+	return sessionId, nil
+}
+
+// GetRoleByToken falls back to the user role for tokens issued before roles
+// were added to the claims.
+func (j *jwtService) GetRoleByToken(token string) (string, error) {
+	t_Token, err := jwt.Parse(token, j.parseToken)
+	if err != nil {
+		return "", err
+	}
+
+	claims := t_Token.Claims.(jwt.MapClaims)
+	role, ok := claims["role"].(string)
+	if !ok || role == "" {
+		return constants.ENUM_ROLE_USER, nil
+	}
+
+	return role, nil
+}
+
+// ValidatePersonalAccessToken lives next to ValidateToken since Authenticate
+// accepts both. Tokens of suspended or deleted accounts stop working right
+// away, sessions of those accounts are revoked instead.
+func (j *jwtService) ValidatePersonalAccessToken(token string) (entity.PersonalAccessToken, error) {
+	ctx := context.Background()
+
+	pat, err := j.personalAccessTokenRepo.GetActivePersonalAccessTokenByHash(ctx, nil, helpers.HashToken(token))
+	if err != nil {
+		return entity.PersonalAccessToken{}, dto.ErrInvalidPersonalAccessToken
+	}
+
+	if pat.User.ID == uuid.Nil || pat.User.SuspendedAt != nil || pat.User.DeletionScheduledAt != nil {
+		return entity.PersonalAccessToken{}, dto.ErrInvalidPersonalAccessToken
+	}
+
+	now := time.Now()
+	if err := j.personalAccessTokenRepo.TouchPersonalAccessToken(ctx, nil, pat.ID.String(), now, now.Add(-personalAccessTokenTouchInterval)); err != nil {
+		log.Println(err)
+	}
+
+	return pat, nil
+}
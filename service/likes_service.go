@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"log"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
 )
@@ -14,26 +16,45 @@ type (
 	}
 
 	likesService struct {
-		likesRepo  repository.LikesRepository
-		postRepo   repository.PostRepository
-		jwtService JWTService
+		likesRepo           repository.LikesRepository
+		postRepo            repository.PostRepository
+		blockRepo           repository.BlockRepository
+		notificationService NotificationService
+		jwtService          JWTService
 	}
 )
 
-func NewLikesService(likesRepo repository.LikesRepository, postRepo repository.PostRepository, jwtService JWTService) LikesService {
+func NewLikesService(likesRepo repository.LikesRepository, postRepo repository.PostRepository, blockRepo repository.BlockRepository, notificationService NotificationService, jwtService JWTService) LikesService {
 	return &likesService{
-		likesRepo:  likesRepo,
-		postRepo:   postRepo,
-		jwtService: jwtService,
+		likesRepo:           likesRepo,
+		postRepo:            postRepo,
+		blockRepo:           blockRepo,
+		notificationService: notificationService,
+		jwtService:          jwtService,
 	}
 }
 
 func (s *likesService) LikePostById(ctx context.Context, postId uint64, userId string) error {
-	_, err := s.postRepo.GetPostById(ctx, nil, postId)
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
 	if err != nil {
 		return dto.ErrGetPostById
 	}
 
+	post, ok := originalPost(post)
+	if !ok {
+		return dto.ErrGetPostById
+	}
+	postId = post.ID
+
+	blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, post.UserID.String())
+	if err != nil {
+		return dto.ErrCheckBlocked
+	}
+
+	if blocked {
+		return dto.ErrBlocked
+	}
+
 	err = s.likesRepo.LikePostById(ctx, nil, postId, userId)
 	if err != nil {
 		return dto.ErrLikePostById
@@ -44,11 +65,24 @@ func (s *likesService) LikePostById(ctx context.Context, postId uint64, userId s
 		return dto.ErrLikePostById
 	}
 
+	if err := s.notificationService.Notify(ctx, constants.ENUM_NOTIFICATION_LIKE, userId, post.UserID.String(), &postId); err != nil {
+		log.Println(err)
+	}
+
 	return nil
 }
 
+// UnLikePostById also works on posts deleted since they were liked, the post
+// is only looked up to retract the notification of its author.
 func (s *likesService) UnLikePostById(ctx context.Context, postId uint64, userId string) error {
-	err := s.likesRepo.CheckLikedPost(ctx, nil, postId, userId)
+	postId = originalPostId(ctx, s.postRepo, postId)
+
+	post, err := s.postRepo.GetPostByIdWithDeleted(ctx, nil, postId)
+	if err != nil {
+		return dto.ErrGetPostById
+	}
+
+	err = s.likesRepo.CheckLikedPost(ctx, nil, postId, userId)
 	if err != nil {
 		return dto.ErrCheckLikedPost
 	}
@@ -63,5 +97,9 @@ func (s *likesService) UnLikePostById(ctx context.Context, postId uint64, userId
 		return dto.ErrUnlikePostById
 	}
 
+	if err := s.notificationService.Retract(ctx, constants.ENUM_NOTIFICATION_LIKE, userId, post.UserID.String(), &postId); err != nil {
+		log.Println(err)
+	}
+
 	return nil
 }
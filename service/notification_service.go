@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	NotificationService interface {
+		Notify(ctx context.Context, notifyType string, actorId string, recipientId string, postId *uint64) error
+		Retract(ctx context.Context, notifyType string, actorId string, recipientId string, postId *uint64) error
+		GetNotifications(ctx context.Context, userId string, req dto.PaginationRequest) (dto.NotificationPaginationResponse, error)
+		GetUnreadCount(ctx context.Context, userId string) (dto.NotificationUnreadResponse, error)
+		MarkAsRead(ctx context.Context, userId string, req dto.NotificationReadRequest) error
+	}
+
+	notificationService struct {
+		notificationRepo repository.NotificationRepository
+		blockRepo        repository.BlockRepository
+		storage          utils.Storage
+	}
+)
+
+func NewNotificationService(notificationRepo repository.NotificationRepository, blockRepo repository.BlockRepository, storage utils.Storage) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		blockRepo:        blockRepo,
+		storage:          storage,
+	}
+}
+
+// Notify records an event for the recipient, users are never notified
+// about their own actions or by users they blocked, were blocked by or muted.
+func (s *notificationService) Notify(ctx context.Context, notifyType string, actorId string, recipientId string, postId *uint64) error {
+	if actorId == recipientId {
+		return nil
+	}
+
+	hiddenIds, err := s.blockRepo.GetHiddenUserIds(ctx, nil, recipientId, []string{actorId})
+	if err != nil {
+		return dto.ErrCreateNotification
+	}
+
+	if len(hiddenIds) > 0 {
+		return nil
+	}
+
+	notification := entity.Notification{
+		Type:        notifyType,
+		GroupKey:    notificationGroupKey(notifyType, postId),
+		RecipientID: uuid.MustParse(recipientId),
+		ActorID:     uuid.MustParse(actorId),
+		PostID:      postId,
+	}
+
+	if err := s.notificationRepo.CreateNotification(ctx, nil, notification); err != nil {
+		return dto.ErrCreateNotification
+	}
+
+	return nil
+}
+
+// Retract removes an event that was undone, like an unlike or an unfollow
+func (s *notificationService) Retract(ctx context.Context, notifyType string, actorId string, recipientId string, postId *uint64) error {
+	if err := s.notificationRepo.DeleteNotification(ctx, nil, recipientId, actorId, notificationGroupKey(notifyType, postId)); err != nil {
+		return dto.ErrDeleteNotification
+	}
+
+	return nil
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, userId string, req dto.PaginationRequest) (dto.NotificationPaginationResponse, error) {
+	dataWithPaginate, err := s.notificationRepo.GetNotificationsWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.NotificationPaginationResponse{}, dto.ErrGetNotifications
+	}
+
+	unread, err := s.notificationRepo.CountUnreadGroups(ctx, nil, userId)
+	if err != nil {
+		return dto.NotificationPaginationResponse{}, dto.ErrGetUnreadCount
+	}
+
+	data := make([]dto.NotificationResponse, 0, len(dataWithPaginate.Groups))
+	for _, group := range dataWithPaginate.Groups {
+		data = append(data, toNotificationResponse(s.storage, group))
+	}
+
+	return dto.NotificationPaginationResponse{
+		Data: data,
+		NotificationMetaResponse: dto.NotificationMetaResponse{
+			PaginationResponse: dto.PaginationResponse{
+				Page:    dataWithPaginate.Page,
+				PerPage: dataWithPaginate.PerPage,
+				MaxPage: dataWithPaginate.MaxPage,
+				Count:   dataWithPaginate.Count,
+			},
+			UnreadCount: unread,
+		},
+	}, nil
+}
+
+func (s *notificationService) GetUnreadCount(ctx context.Context, userId string) (dto.NotificationUnreadResponse, error) {
+	unread, err := s.notificationRepo.CountUnreadGroups(ctx, nil, userId)
+	if err != nil {
+		return dto.NotificationUnreadResponse{}, dto.ErrGetUnreadCount
+	}
+
+	return dto.NotificationUnreadResponse{
+		UnreadCount: unread,
+	}, nil
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, userId string, req dto.NotificationReadRequest) error {
+	if err := s.notificationRepo.MarkAsRead(ctx, nil, userId, req.GroupKey); err != nil {
+		return dto.ErrReadNotifications
+	}
+
+	return nil
+}
+
+// notificationGroupKey groups follows together and every other event by
+// type and post.
+func notificationGroupKey(notifyType string, postId *uint64) string {
+	if postId == nil {
+		return notifyType
+	}
+
+	return fmt.Sprintf("%s:%d", notifyType, *postId)
+}
+
+func toNotificationResponse(storage utils.Storage, group dto.NotificationGroup) dto.NotificationResponse {
+	response := dto.NotificationResponse{
+		GroupKey:    group.GroupKey,
+		Type:        group.Type,
+		Actors:      make([]dto.UserResponse, 0, len(group.Actors)),
+		TotalActors: group.TotalActors,
+		IsRead:      group.IsRead,
+		CreatedAt:   group.LatestAt,
+	}
+
+	for _, notification := range group.Actors {
+		response.Actors = append(response.Actors, toUserResponse(storage, notification.Actor))
+		if response.Post == nil && notification.Post != nil {
+			post := toPostResponse(storage, *notification.Post)
+			response.Post = &post
+		}
+	}
+
+	if len(response.Actors) > 0 {
+		response.Message = notificationMessage(group.Type, response.Actors[0].Name, group.TotalActors-1)
+	}
+
+	return response
+}
+
+// notificationMessage renders e.g. "Alice and 4 others liked your post"
+func notificationMessage(notifyType string, actorName string, others int64) string {
+	var action string
+	switch notifyType {
+	case constants.ENUM_NOTIFICATION_LIKE:
+		action = "liked your post"
+	case constants.ENUM_NOTIFICATION_REPLY:
+		action = "replied to your post"
+	case constants.ENUM_NOTIFICATION_FOLLOW:
+		action = "followed you"
+	case constants.ENUM_NOTIFICATION_MENTION:
+		action = "mentioned you"
+	}
+
+	switch {
+	case others == 1:
+		return fmt.Sprintf("%s and 1 other %s", actorName, action)
+	case others > 1:
+		return fmt.Sprintf("%s and %d others %s", actorName, others, action)
+	default:
+		return fmt.Sprintf("%s %s", actorName, action)
+	}
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+)
+
+// characters dropped from provider profile names when deriving a username
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+type (
+	OIDCService interface {
+		GetProviders() []string
+		Authorize(ctx context.Context, provider string) (dto.OIDCAuthorizeResponse, error)
+		AuthorizeLink(ctx context.Context, userId string, provider string) (dto.OIDCAuthorizeResponse, error)
+		Login(ctx context.Context, provider string, req dto.OIDCCallbackRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error)
+		Link(ctx context.Context, userId string, provider string, req dto.OIDCCallbackRequest) error
+	}
+
+	oidcService struct {
+		userRepo         repository.UserRepository
+		oidcRepo         repository.OIDCRepository
+		sessionService   SessionService
+		twoFactorService TwoFactorService
+		oidcConfig       config.OIDCConfig
+		clients          map[string]*utils.OIDCClient
+	}
+)
+
+func NewOIDCService(userRepo repository.UserRepository, oidcRepo repository.OIDCRepository, sessionService SessionService, twoFactorService TwoFactorService, oidcConfig config.OIDCConfig) OIDCService {
+	clients := map[string]*utils.OIDCClient{}
+	for _, provider := range oidcConfig.Providers {
+		clients[provider.Name] = utils.NewOIDCClient(provider)
+	}
+
+	return &oidcService{
+		userRepo:         userRepo,
+		oidcRepo:         oidcRepo,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		oidcConfig:       oidcConfig,
+		clients:          clients,
+	}
+}
+
+func (s *oidcService) GetProviders() []string {
+	providers := make([]string, 0, len(s.oidcConfig.Providers))
+	for _, provider := range s.oidcConfig.Providers {
+		providers = append(providers, provider.Name)
+	}
+
+	return providers
+}
+
+// Authorize starts a login, the client opens the returned url and hands the
+// code and state from the redirect back to Login.
+func (s *oidcService) Authorize(ctx context.Context, provider string) (dto.OIDCAuthorizeResponse, error) {
+	return s.authorize(ctx, provider, entity.OIDCState{Purpose: constants.ENUM_OIDC_PURPOSE_LOGIN})
+}
+
+// AuthorizeLink starts linking a provider account to the signed-in user, the
+// state only finishes a Link by the same user.
+func (s *oidcService) AuthorizeLink(ctx context.Context, userId string, provider string) (dto.OIDCAuthorizeResponse, error) {
+	id, err := uuid.Parse(userId)
+	if err != nil {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	return s.authorize(ctx, provider, entity.OIDCState{Purpose: constants.ENUM_OIDC_PURPOSE_LINK, UserID: &id})
+}
+
+func (s *oidcService) authorize(ctx context.Context, provider string, pending entity.OIDCState) (dto.OIDCAuthorizeResponse, error) {
+	client, ok := s.clients[provider]
+	if !ok {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCProviderNotFound
+	}
+
+	state, err := helpers.GenerateToken()
+	if err != nil {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	nonce, err := helpers.GenerateToken()
+	if err != nil {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	verifier, err := helpers.GenerateToken()
+	if err != nil {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	authorizationURL, err := client.AuthorizationURL(ctx, state, nonce, helpers.PKCEChallenge(verifier))
+	if err != nil {
+		log.Println(err)
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	pending.StateHash = helpers.HashToken(state)
+	pending.Provider = provider
+	pending.CodeVerifier = verifier
+	pending.Nonce = nonce
+	pending.ExpiresAt = time.Now().Add(s.oidcConfig.StateTTL)
+
+	if err := s.oidcRepo.CreateOIDCState(ctx, nil, pending); err != nil {
+		return dto.OIDCAuthorizeResponse{}, dto.ErrOIDCAuthorize
+	}
+
+	return dto.OIDCAuthorizeResponse{
+		AuthorizationURL: authorizationURL,
+		State:            state,
+	}, nil
+}
+
+// Login signs in the user linked to the provider account. Unknown accounts
+// are linked to the user with the same verified email, or get a new user.
+func (s *oidcService) Login(ctx context.Context, provider string, req dto.OIDCCallbackRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error) {
+	claims, err := s.exchange(ctx, provider, constants.ENUM_OIDC_PURPOSE_LOGIN, "", req)
+	if err != nil {
+		return dto.UserLoginResponse{}, err
+	}
+
+	identity, found, err := s.oidcRepo.GetUserIdentity(ctx, nil, provider, claims.Subject)
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrOIDCLogin
+	}
+
+	user := identity.User
+	if !found {
+		user, err = s.linkOrRegister(ctx, provider, claims)
+		if err != nil {
+			return dto.UserLoginResponse{}, err
+		}
+	}
+
+	if user.SuspendedAt != nil {
+		return dto.UserLoginResponse{}, dto.ErrUserSuspended
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return s.twoFactorService.IssueChallenge(ctx, user.ID.String())
+	}
+
+	return s.sessionService.CreateSession(ctx, user.ID.String(), meta)
+}
+
+// Link adds a provider account to the signed-in user, for accounts whose
+// email doesn't match or wasn't verified by the provider.
+func (s *oidcService) Link(ctx context.Context, userId string, provider string, req dto.OIDCCallbackRequest) error {
+	claims, err := s.exchange(ctx, provider, constants.ENUM_OIDC_PURPOSE_LINK, userId, req)
+	if err != nil {
+		return err
+	}
+
+	identity, found, err := s.oidcRepo.GetUserIdentity(ctx, nil, provider, claims.Subject)
+	if err != nil {
+		return dto.ErrOIDCLogin
+	}
+
+	if found {
+		if identity.UserID.String() != userId {
+			return dto.ErrOIDCIdentityInUse
+		}
+		return nil
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if err := s.oidcRepo.CreateUserIdentity(ctx, nil, newUserIdentity(provider, claims, user)); err != nil {
+		return dto.ErrOIDCLogin
+	}
+
+	return nil
+}
+
+// exchange uses up the state and redeems the code with its PKCE verifier. The
+// state has to be started for the same purpose, and for links by the same
+// user, so a login redirect can't be used to link someone else's account.
+func (s *oidcService) exchange(ctx context.Context, provider string, purpose string, userId string, req dto.OIDCCallbackRequest) (utils.OIDCClaims, error) {
+	client, ok := s.clients[provider]
+	if !ok {
+		return utils.OIDCClaims{}, dto.ErrOIDCProviderNotFound
+	}
+
+	state, err := s.oidcRepo.GetOIDCStateByHash(ctx, nil, helpers.HashToken(req.State))
+	if err != nil || state.Provider != provider || state.Purpose != purpose || state.UsedAt != nil || state.ExpiresAt.Before(time.Now()) {
+		return utils.OIDCClaims{}, dto.ErrOIDCInvalidState
+	}
+
+	if purpose == constants.ENUM_OIDC_PURPOSE_LINK && (state.UserID == nil || state.UserID.String() != userId) {
+		return utils.OIDCClaims{}, dto.ErrOIDCInvalidState
+	}
+
+	claimed, err := s.oidcRepo.MarkOIDCStateUsed(ctx, nil, state.ID)
+	if err != nil || !claimed {
+		return utils.OIDCClaims{}, dto.ErrOIDCInvalidState
+	}
+
+	claims, err := client.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		log.Println(err)
+		return utils.OIDCClaims{}, dto.ErrOIDCLogin
+	}
+
+	return claims, nil
+}
+
+// linkOrRegister only trusts emails both sides verified, otherwise anyone
+// could take over an account by registering its email at a provider first.
+func (s *oidcService) linkOrRegister(ctx context.Context, provider string, claims utils.OIDCClaims) (entity.User, error) {
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email != "" {
+		existing, found, _ := s.userRepo.CheckEmail(ctx, nil, email)
+		if found {
+			if !claims.EmailVerified || existing.EmailVerifiedAt == nil {
+				return entity.User{}, dto.ErrOIDCEmailInUse
+			}
+
+			if err := s.oidcRepo.CreateUserIdentity(ctx, nil, newUserIdentity(provider, claims, existing)); err != nil {
+				return entity.User{}, dto.ErrOIDCLogin
+			}
+
+			return existing, nil
+		}
+	}
+
+	username, err := s.generateUsername(ctx, claims)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	// the random password is never told to the user, PasswordUnset lets them
+	// set a first one without it
+	password, err := helpers.GenerateToken()
+	if err != nil {
+		return entity.User{}, dto.ErrCreateUser
+	}
+
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name = username
+	}
+
+	user := entity.User{
+		Name:          name,
+		Username:      username,
+		Password:      password,
+		PasswordUnset: true,
+		Role:          constants.ENUM_ROLE_USER,
+	}
+
+	if email != "" && claims.EmailVerified {
+		now := time.Now()
+		user.Email = &email
+		user.EmailVerifiedAt = &now
+	}
+
+	user, err = s.oidcRepo.RegisterUserWithIdentity(ctx, nil, user, newUserIdentity(provider, claims, entity.User{}))
+	if err != nil {
+		return entity.User{}, dto.ErrCreateUser
+	}
+
+	return user, nil
+}
+
+// generateUsername derives a name from the provider profile and looks it up
+// with CheckUsername like registration does, appending a number while taken.
+func (s *oidcService) generateUsername(ctx context.Context, claims utils.OIDCClaims) (string, error) {
+	base := ""
+	for _, candidate := range []string{claims.PreferredUsername, strings.Split(claims.Email, "@")[0], claims.Name} {
+		base = usernameInvalidChars.ReplaceAllString(strings.ToLower(candidate), "")
+		if len(base) >= 3 {
+			break
+		}
+	}
+
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	for i := 0; i < 100; i++ {
+		suffix := ""
+		if i > 0 {
+			suffix = strconv.Itoa(i)
+		}
+
+		username := base
+		if len(username)+len(suffix) > constants.ENUM_OIDC_USERNAME_MAX_LENGTH {
+			username = username[:constants.ENUM_OIDC_USERNAME_MAX_LENGTH-len(suffix)]
+		}
+		username += suffix
+
+		if _, taken, _ := s.userRepo.CheckUsername(ctx, nil, username); !taken {
+			return username, nil
+		}
+	}
+
+	return "", dto.ErrGenerateUsername
+}
+
+func newUserIdentity(provider string, claims utils.OIDCClaims, user entity.User) entity.UserIdentity {
+	identity := entity.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		UserID:   user.ID,
+	}
+
+	if claims.Email != "" {
+		email := strings.ToLower(claims.Email)
+		identity.Email = &email
+	}
+
+	return identity
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+)
+
+type (
+	PasswordService interface {
+		ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest)
+		ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+		ChangePassword(ctx context.Context, userId string, sessionId string, req dto.ChangePasswordRequest) error
+	}
+
+	passwordService struct {
+		userRepo            repository.UserRepository
+		passwordResetRepo   repository.PasswordResetRepository
+		sessionService      SessionService
+		mailer              utils.Mailer
+		passwordResetConfig config.PasswordResetConfig
+	}
+)
+
+func NewPasswordService(userRepo repository.UserRepository, passwordResetRepo repository.PasswordResetRepository, sessionService SessionService, mailer utils.Mailer, passwordResetConfig config.PasswordResetConfig) PasswordService {
+	return &passwordService{
+		userRepo:            userRepo,
+		passwordResetRepo:   passwordResetRepo,
+		sessionService:      sessionService,
+		mailer:              mailer,
+		passwordResetConfig: passwordResetConfig,
+	}
+}
+
+// ForgotPassword has no result on purpose, callers get the same answer
+// whether the account exists or not. The lookup and the email run in the
+// background so response times don't tell them apart either.
+func (s *passwordService) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) {
+	go func() {
+		if err := s.sendPasswordReset(context.WithoutCancel(ctx), strings.TrimSpace(req.Identifier)); err != nil {
+			log.Println(err)
+		}
+	}()
+}
+
+// ResetPassword sets the new password and signs the user out everywhere,
+// the token and every other pending one for the user stop working.
+func (s *passwordService) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	reset, err := s.passwordResetRepo.GetPasswordResetByHash(ctx, nil, helpers.HashToken(req.Token))
+	if err != nil {
+		return dto.ErrInvalidPasswordReset
+	}
+
+	if reset.UsedAt != nil || reset.ExpiresAt.Before(time.Now()) {
+		return dto.ErrInvalidPasswordReset
+	}
+
+	fresh, err := s.passwordResetRepo.MarkPasswordResetUsed(ctx, nil, reset.ID)
+	if err != nil || !fresh {
+		return dto.ErrInvalidPasswordReset
+	}
+
+	userId := reset.UserID.String()
+	password, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		return dto.ErrResetPassword
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, nil, userId, password); err != nil {
+		return dto.ErrResetPassword
+	}
+
+	if err := s.passwordResetRepo.InvalidatePasswordResets(ctx, nil, userId); err != nil {
+		return dto.ErrResetPassword
+	}
+
+	return s.sessionService.LogoutAll(ctx, userId)
+}
+
+// ChangePassword keeps the current session signed in and revokes the others.
+// Accounts registered through an identity provider set their first password
+// here without a current one.
+func (s *passwordService) ChangePassword(ctx context.Context, userId string, sessionId string, req dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if !user.PasswordUnset {
+		checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.CurrentPassword))
+		if err != nil || !checkPassword {
+			return dto.ErrPasswordNotMatch
+		}
+
+		if req.NewPassword == req.CurrentPassword {
+			return dto.ErrSamePassword
+		}
+	}
+
+	password, err := helpers.HashPassword(req.NewPassword)
+	if err != nil {
+		return dto.ErrChangePassword
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, nil, userId, password); err != nil {
+		return dto.ErrChangePassword
+	}
+
+	if err := s.passwordResetRepo.InvalidatePasswordResets(ctx, nil, userId); err != nil {
+		return dto.ErrChangePassword
+	}
+
+	return s.sessionService.LogoutOthers(ctx, userId, sessionId)
+}
+
+func (s *passwordService) sendPasswordReset(ctx context.Context, identifier string) error {
+	var user entity.User
+	var found bool
+	if strings.Contains(identifier, "@") {
+		user, found, _ = s.userRepo.CheckEmail(ctx, nil, strings.ToLower(identifier))
+	} else {
+		user, found, _ = s.userRepo.CheckUsername(ctx, nil, identifier)
+	}
+
+	if !found || user.Email == nil {
+		return nil
+	}
+
+	token, err := helpers.GenerateToken()
+	if err != nil {
+		return err
+	}
+
+	reset := entity.PasswordReset{
+		TokenHash: helpers.HashToken(token),
+		ExpiresAt: time.Now().Add(s.passwordResetConfig.TTL),
+		UserID:    user.ID,
+	}
+
+	if err := s.passwordResetRepo.CreatePasswordReset(ctx, nil, reset); err != nil {
+		return err
+	}
+
+	body, err := utils.ParseEmailTemplate("reset_password", map[string]string{
+		"Email":     *user.Email,
+		"Reset":     s.passwordResetConfig.URL + "?token=" + url.QueryEscape(token),
+		"ExpiresIn": fmt.Sprintf("%d minutes", int(s.passwordResetConfig.TTL.Minutes())),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(*user.Email, "Reset your password", body)
+}
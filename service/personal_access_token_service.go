@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/google/uuid"
+)
+
+// scopes a personal access token can be granted
+var personalAccessTokenScopes = []string{
+	constants.ENUM_SCOPE_READ,
+	constants.ENUM_SCOPE_POST_WRITE,
+	constants.ENUM_SCOPE_LIKES_WRITE,
+}
+
+type (
+	PersonalAccessTokenService interface {
+		CreatePersonalAccessToken(ctx context.Context, userId string, req dto.PersonalAccessTokenCreateRequest) (dto.PersonalAccessTokenCreateResponse, error)
+		GetPersonalAccessTokens(ctx context.Context, userId string) ([]dto.PersonalAccessTokenResponse, error)
+		RevokePersonalAccessToken(ctx context.Context, userId string, tokenId string) error
+	}
+
+	personalAccessTokenService struct {
+		personalAccessTokenRepo repository.PersonalAccessTokenRepository
+	}
+)
+
+func NewPersonalAccessTokenService(personalAccessTokenRepo repository.PersonalAccessTokenRepository) PersonalAccessTokenService {
+	return &personalAccessTokenService{
+		personalAccessTokenRepo: personalAccessTokenRepo,
+	}
+}
+
+func (s *personalAccessTokenService) CreatePersonalAccessToken(ctx context.Context, userId string, req dto.PersonalAccessTokenCreateRequest) (dto.PersonalAccessTokenCreateResponse, error) {
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if !slices.Contains(personalAccessTokenScopes, scope) {
+			return dto.PersonalAccessTokenCreateResponse{}, dto.ErrInvalidScope
+		}
+
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	count, err := s.personalAccessTokenRepo.CountActivePersonalAccessTokens(ctx, nil, userId)
+	if err != nil {
+		return dto.PersonalAccessTokenCreateResponse{}, dto.ErrCreatePersonalAccessToken
+	}
+
+	if count >= constants.ENUM_PERSONAL_ACCESS_TOKEN_LIMIT {
+		return dto.PersonalAccessTokenCreateResponse{}, dto.ErrPersonalAccessTokenLimit
+	}
+
+	secret, err := helpers.GenerateToken()
+	if err != nil {
+		return dto.PersonalAccessTokenCreateResponse{}, dto.ErrCreatePersonalAccessToken
+	}
+	token := constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX + secret
+
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		expires := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		expiresAt = &expires
+	}
+
+	created, err := s.personalAccessTokenRepo.CreatePersonalAccessToken(ctx, nil, entity.PersonalAccessToken{
+		Name:      req.Name,
+		TokenHash: helpers.HashToken(token),
+		Hint:      token[:len(constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX)+4],
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: expiresAt,
+		UserID:    uuid.MustParse(userId),
+	})
+	if err != nil {
+		return dto.PersonalAccessTokenCreateResponse{}, dto.ErrCreatePersonalAccessToken
+	}
+
+	return dto.PersonalAccessTokenCreateResponse{
+		PersonalAccessTokenResponse: toPersonalAccessTokenResponse(created),
+		Token:                       token,
+	}, nil
+}
+
+func (s *personalAccessTokenService) GetPersonalAccessTokens(ctx context.Context, userId string) ([]dto.PersonalAccessTokenResponse, error) {
+	tokens, err := s.personalAccessTokenRepo.GetActivePersonalAccessTokensByUserId(ctx, nil, userId)
+	if err != nil {
+		return nil, dto.ErrGetPersonalAccessTokens
+	}
+
+	data := make([]dto.PersonalAccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		data = append(data, toPersonalAccessTokenResponse(token))
+	}
+
+	return data, nil
+}
+
+func (s *personalAccessTokenService) RevokePersonalAccessToken(ctx context.Context, userId string, tokenId string) error {
+	if _, err := uuid.Parse(tokenId); err != nil {
+		return dto.ErrPersonalAccessTokenNotFound
+	}
+
+	revoked, err := s.personalAccessTokenRepo.RevokePersonalAccessToken(ctx, nil, userId, tokenId)
+	if err != nil {
+		return dto.ErrRevokePersonalAccessToken
+	}
+
+	if !revoked {
+		return dto.ErrPersonalAccessTokenNotFound
+	}
+
+	return nil
+}
+
+func toPersonalAccessTokenResponse(token entity.PersonalAccessToken) dto.PersonalAccessTokenResponse {
+	return dto.PersonalAccessTokenResponse{
+		ID:         token.ID.String(),
+		Name:       token.Name,
+		Hint:       token.Hint,
+		Scopes:     strings.Fields(token.Scopes),
+		LastUsedAt: token.LastUsedAt,
+		ExpiresAt:  token.ExpiresAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -2,56 +2,176 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
 	"github.com/google/uuid"
 )
 
 type (
 	PostService interface {
 		CreatePost(ctx context.Context, userId string, req dto.PostCreateRequest) (dto.PostResponse, error)
-		GetPostById(ctx context.Context, postId uint64, req dto.PaginationRequest) (dto.PostRepliesPaginationResponse, error)
-		DeletePostById(ctx context.Context, postId uint64) error
+		GetPostById(ctx context.Context, viewerId string, postId uint64, req dto.PaginationRequest) (dto.PostRepliesPaginationResponse, error)
+		DeletePostById(ctx context.Context, userId string, postId uint64) error
 		UpdatePostById(ctx context.Context, userId string, postId uint64, req dto.PostUpdateRequest) (dto.PostResponse, error)
-		GetAllPosts(ctx context.Context, req dto.PaginationRequest) (dto.PostPaginationResponse, error)
+		GetPostHistory(ctx context.Context, postId uint64) (dto.PostHistoryResponse, error)
+		GetAllPosts(ctx context.Context, viewerId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error)
+		GetTimeline(ctx context.Context, userId string, req dto.TimelinePaginationRequest) (dto.TimelinePaginationResponse, error)
+		RepostPostById(ctx context.Context, postId uint64, userId string) error
+		UnrepostPostById(ctx context.Context, postId uint64, userId string) error
+		VotePoll(ctx context.Context, userId string, postId uint64, req dto.PollVoteRequest) (dto.PollResponse, error)
 	}
 
 	postService struct {
-		userRepo   repository.UserRepository
-		postRepo   repository.PostRepository
-		jwtService JWTService
+		userRepo            repository.UserRepository
+		postRepo            repository.PostRepository
+		postRevisionRepo    repository.PostRevisionRepository
+		timelineRepo        repository.TimelineRepository
+		bookmarkRepo        repository.BookmarkRepository
+		mentionRepo         repository.MentionRepository
+		hashtagRepo         repository.HashtagRepository
+		blockRepo           repository.BlockRepository
+		pollRepo            repository.PollRepository
+		notificationService NotificationService
+		jwtService          JWTService
+		editConfig          config.PostEditConfig
+		mediaConfig         config.MediaConfig
+		storage             utils.Storage
 	}
 )
 
-func NewPostService(userRepo repository.UserRepository, postRepo repository.PostRepository, jwtService JWTService) PostService {
+func NewPostService(userRepo repository.UserRepository, postRepo repository.PostRepository, postRevisionRepo repository.PostRevisionRepository, timelineRepo repository.TimelineRepository, bookmarkRepo repository.BookmarkRepository, mentionRepo repository.MentionRepository, hashtagRepo repository.HashtagRepository, blockRepo repository.BlockRepository, pollRepo repository.PollRepository, notificationService NotificationService, jwtService JWTService, editConfig config.PostEditConfig, mediaConfig config.MediaConfig, storage utils.Storage) PostService {
 	return &postService{
-		userRepo:   userRepo,
-		postRepo:   postRepo,
-		jwtService: jwtService,
+		userRepo:            userRepo,
+		postRepo:            postRepo,
+		postRevisionRepo:    postRevisionRepo,
+		timelineRepo:        timelineRepo,
+		bookmarkRepo:        bookmarkRepo,
+		mentionRepo:         mentionRepo,
+		hashtagRepo:         hashtagRepo,
+		blockRepo:           blockRepo,
+		pollRepo:            pollRepo,
+		notificationService: notificationService,
+		jwtService:          jwtService,
+		editConfig:          editConfig,
+		mediaConfig:         mediaConfig,
+		storage:             storage,
 	}
 }
 
 func (s *postService) CreatePost(ctx context.Context, userId string, req dto.PostCreateRequest) (dto.PostResponse, error) {
+	if err := s.checkVerified(ctx, userId); err != nil {
+		return dto.PostResponse{}, err
+	}
+
+	if strings.TrimSpace(req.Text) == "" && len(req.Media) == 0 && len(req.PollOptions) == 0 {
+		return dto.PostResponse{}, dto.ErrEmptyPost
+	}
+
+	poll, err := newPoll(req.PollOptions, req.PollDurationMinutes)
+	if err != nil {
+		return dto.PostResponse{}, err
+	}
+
+	if poll != nil && len(req.Media) > 0 {
+		return dto.PostResponse{}, dto.ErrPollWithMedia
+	}
+
+	var parent entity.Post
 	if req.ParentID != nil {
-		_, err := s.postRepo.GetPostById(ctx, nil, *req.ParentID)
+		var err error
+		parent, err = s.postRepo.GetPostById(ctx, nil, *req.ParentID)
 		if err != nil {
 			return dto.PostResponse{}, dto.ErrGetPostById
 		}
+
+		blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, parent.UserID.String())
+		if err != nil {
+			return dto.PostResponse{}, dto.ErrCheckBlocked
+		}
+
+		if blocked {
+			return dto.PostResponse{}, dto.ErrBlocked
+		}
+	}
+
+	if req.QuotedPostID != nil {
+		quoted, err := s.postRepo.GetPostById(ctx, nil, *req.QuotedPostID)
+		if err != nil {
+			return dto.PostResponse{}, dto.ErrGetQuotedPost
+		}
+
+		quoted, ok := originalPost(quoted)
+		if !ok {
+			return dto.PostResponse{}, dto.ErrGetQuotedPost
+		}
+		req.QuotedPostID = &quoted.ID
+
+		blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, quoted.UserID.String())
+		if err != nil {
+			return dto.PostResponse{}, dto.ErrCheckBlocked
+		}
+
+		if blocked {
+			return dto.PostResponse{}, dto.ErrBlocked
+		}
+	}
+
+	attachments, err := s.saveMedia(ctx, req.Media, req.AltText)
+	if err != nil {
+		return dto.PostResponse{}, err
 	}
 
 	post := entity.Post{
-		Text:     req.Text,
-		UserID:   uuid.MustParse(userId),
-		ParentID: req.ParentID,
+		Text:         req.Text,
+		UserID:       uuid.MustParse(userId),
+		ParentID:     req.ParentID,
+		QuotedPostID: req.QuotedPostID,
+		Attachments:  attachments,
+		Poll:         poll,
 	}
 
 	result, err := s.postRepo.CreatePost(ctx, nil, post)
 	if err != nil {
+		s.deleteMedia(ctx, attachments)
 		return dto.PostResponse{}, dto.ErrCreatePost
 	}
 
+	result.Mentions, err = s.saveMentions(ctx, result)
+	if err != nil {
+		return dto.PostResponse{}, dto.ErrSaveMentions
+	}
+
+	if err := s.saveHashtags(ctx, result); err != nil {
+		return dto.PostResponse{}, dto.ErrSaveHashtags
+	}
+
+	// the post is saved at this point, a lost notification or timeline entry doesn't fail it
+	if result.ParentID != nil {
+		if err := s.notificationService.Notify(ctx, constants.ENUM_NOTIFICATION_REPLY, userId, parent.UserID.String(), result.ParentID); err != nil {
+			log.Println(err)
+		}
+	}
+
+	s.notifyMentions(ctx, result, nil)
+
+	if result.ParentID == nil {
+		if err := s.timelineRepo.AddPostToTimelines(ctx, nil, result); err != nil {
+			log.Println(err)
+		}
+	}
+
 	user, err := s.userRepo.GetUserById(ctx, nil, userId)
 	if err != nil {
 		return dto.PostResponse{}, dto.ErrGetUserById
@@ -67,74 +187,53 @@ func (s *postService) CreatePost(ctx context.Context, userId string, req dto.Pos
 		}, nil
 	}
 
-	return dto.PostResponse{
-		ID:         result.ID,
-		Text:       result.Text,
-		TotalLikes: result.TotalLikes,
-		IsDeleted:  result.DeletedAt.Valid,
-		ParentID:   req.ParentID,
-		User: dto.UserResponse{
-			ID:       user.ID.String(),
-			Name:     user.Name,
-			Bio:      user.Bio,
-			UserName: user.Username,
-			ImageUrl: user.ImageUrl,
-		},
-	}, nil
+	result.User = user
+	if result.QuotedPostID != nil {
+		if quoted, err := s.postRepo.GetPostById(ctx, nil, *result.QuotedPostID); err == nil {
+			result.QuotedPost = &quoted
+		}
+	}
+
+	return toPostResponse(s.storage, result), nil
 }
 
-func (s *postService) GetPostById(ctx context.Context, postId uint64, req dto.PaginationRequest) (dto.PostRepliesPaginationResponse, error) {
+func (s *postService) GetPostById(ctx context.Context, viewerId string, postId uint64, req dto.PaginationRequest) (dto.PostRepliesPaginationResponse, error) {
 	post, err := s.postRepo.GetPostById(ctx, nil, postId)
 	if err != nil {
 		return dto.PostRepliesPaginationResponse{}, dto.ErrGetPostById
 	}
 
-	replies, err := s.postRepo.GetAllPostRepliesWithPagination(ctx, nil, postId, req)
+	replies, err := s.postRepo.GetAllPostRepliesWithPagination(ctx, nil, viewerId, postId, req)
 	if err != nil {
 		return dto.PostRepliesPaginationResponse{}, dto.ErrGetPostReplies
 	}
 
 	var data []dto.PostResponse
 	for _, reply := range replies.Replies {
-		datum := dto.PostResponse{
-			ID:         reply.ID,
-			Text:       reply.Text,
-			TotalLikes: reply.TotalLikes,
-			IsDeleted:  reply.DeletedAt.Valid,
-			ParentID:   reply.ParentID,
-			User: dto.UserResponse{
-				ID:       reply.UserID.String(),
-				Name:     reply.User.Name,
-				Bio:      reply.User.Bio,
-				UserName: reply.User.Username,
-				ImageUrl: reply.User.ImageUrl,
-			},
-		}
-
-		data = append(data, datum)
+		data = append(data, toPostResponse(s.storage, reply))
 	}
 
 	if data == nil {
 		data = make([]dto.PostResponse, 0)
 	}
 
+	postWithReplies := append([]dto.PostResponse{toPostResponse(s.storage, post)}, data...)
+	if err := markBookmarked(ctx, s.bookmarkRepo, viewerId, postWithReplies); err != nil {
+		return dto.PostRepliesPaginationResponse{}, dto.ErrGetPostById
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, viewerId, postWithReplies); err != nil {
+		return dto.PostRepliesPaginationResponse{}, dto.ErrGetPostById
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, viewerId, postWithReplies); err != nil {
+		return dto.PostRepliesPaginationResponse{}, dto.ErrGetPostById
+	}
+
 	return dto.PostRepliesPaginationResponse{
 		Data: dto.PostWithRepliesResponse{
-			PostResponse: dto.PostResponse{
-				ID:         post.ID,
-				Text:       post.Text,
-				TotalLikes: post.TotalLikes,
-				IsDeleted:  post.DeletedAt.Valid,
-				ParentID:   post.ParentID,
-				User: dto.UserResponse{
-					ID:       post.UserID.String(),
-					Name:     post.User.Name,
-					Bio:      post.User.Bio,
-					UserName: post.User.Username,
-					ImageUrl: post.User.ImageUrl,
-				},
-			},
-			Replies: data,
+			PostResponse: postWithReplies[0],
+			Replies:      postWithReplies[1:],
 		},
 		PaginationResponse: dto.PaginationResponse{
 			Page:    replies.Page,
@@ -145,16 +244,28 @@ func (s *postService) GetPostById(ctx context.Context, postId uint64, req dto.Pa
 	}, nil
 }
 
-func (s *postService) DeletePostById(ctx context.Context, postId uint64) error {
-	_, err := s.postRepo.GetPostById(ctx, nil, postId)
+func (s *postService) DeletePostById(ctx context.Context, userId string, postId uint64) error {
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
 	if err != nil {
 		return dto.ErrGetPostById
 	}
 
+	if post.UserID.String() != userId {
+		return dto.ErrUnauthorized
+	}
+
+	if post.RepostOfID != nil {
+		return s.unrepost(ctx, post)
+	}
+
 	if err := s.postRepo.DeletePostById(ctx, nil, postId); err != nil {
 		return dto.ErrDeletePostById
 	}
 
+	if err := s.timelineRepo.RemovePostFromTimelines(ctx, nil, postId); err != nil {
+		return dto.ErrDeletePostById
+	}
+
 	return nil
 }
 
@@ -168,53 +279,139 @@ func (s *postService) UpdatePostById(ctx context.Context, userId string, postId
 		return dto.PostResponse{}, dto.ErrUnauthorized
 	}
 
-	post.Text = req.Text
+	if post.RepostOfID != nil {
+		return dto.PostResponse{}, dto.ErrUpdatePostById
+	}
 
-	result, err := s.postRepo.UpdatePostById(ctx, nil, postId, post)
+	if req.Text == post.Text {
+		data := []dto.PostResponse{toPostResponse(s.storage, post)}
+		if err := markBookmarked(ctx, s.bookmarkRepo, userId, data); err != nil {
+			return dto.PostResponse{}, dto.ErrUpdatePostById
+		}
+
+		if err := hideQuotedPosts(ctx, s.blockRepo, userId, data); err != nil {
+			return dto.PostResponse{}, dto.ErrUpdatePostById
+		}
+
+		if err := markPollVotes(ctx, s.pollRepo, userId, data); err != nil {
+			return dto.PostResponse{}, dto.ErrUpdatePostById
+		}
+
+		return data[0], nil
+	}
+
+	now := time.Now()
+	if now.Sub(post.CreatedAt) > s.editConfig.Window {
+		return dto.PostResponse{}, dto.ErrEditWindowClosed
+	}
+
+	if post.EditCount >= s.editConfig.MaxEdits {
+		return dto.PostResponse{}, dto.ErrEditLimitReached
+	}
+
+	edited, err := s.postRevisionRepo.EditPost(ctx, nil, post, req.Text, now)
 	if err != nil {
 		return dto.PostResponse{}, dto.ErrUpdatePostById
 	}
 
-	return dto.PostResponse{
-		ID:         result.ID,
-		Text:       result.Text,
-		TotalLikes: result.TotalLikes,
-		IsDeleted:  result.DeletedAt.Valid,
-		ParentID:   result.ParentID,
-		User: dto.UserResponse{
-			ID:       result.UserID.String(),
-			Name:     result.User.Name,
-			Bio:      result.User.Bio,
-			UserName: result.User.Username,
-			ImageUrl: result.User.ImageUrl,
-		},
+	if !edited {
+		return dto.PostResponse{}, dto.ErrEditConflict
+	}
+
+	previousMentions := post.Mentions
+	result := post
+	result.Text = req.Text
+	result.EditCount++
+	result.EditedAt = &now
+
+	result.Mentions, err = s.saveMentions(ctx, result)
+	if err != nil {
+		return dto.PostResponse{}, dto.ErrSaveMentions
+	}
+
+	if err := s.saveHashtags(ctx, result); err != nil {
+		return dto.PostResponse{}, dto.ErrSaveHashtags
+	}
+
+	s.notifyMentions(ctx, result, previousMentions)
+
+	data := []dto.PostResponse{toPostResponse(s.storage, result)}
+	if err := markBookmarked(ctx, s.bookmarkRepo, userId, data); err != nil {
+		return dto.PostResponse{}, dto.ErrUpdatePostById
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, userId, data); err != nil {
+		return dto.PostResponse{}, dto.ErrUpdatePostById
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, userId, data); err != nil {
+		return dto.PostResponse{}, dto.ErrUpdatePostById
+	}
+
+	return data[0], nil
+}
+
+// GetPostHistory returns the current text of a post followed by the texts
+// earlier edits replaced, newest first.
+func (s *postService) GetPostHistory(ctx context.Context, postId uint64) (dto.PostHistoryResponse, error) {
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
+	if err != nil {
+		return dto.PostHistoryResponse{}, dto.ErrGetPostById
+	}
+
+	revisions, err := s.postRevisionRepo.GetRevisionsByPostId(ctx, nil, postId)
+	if err != nil {
+		return dto.PostHistoryResponse{}, dto.ErrGetPostHistory
+	}
+
+	writtenAt := post.CreatedAt
+	if post.EditedAt != nil {
+		writtenAt = *post.EditedAt
+	}
+
+	data := make([]dto.PostRevisionResponse, 0, len(revisions)+1)
+	data = append(data, dto.PostRevisionResponse{
+		Version:   post.EditCount,
+		Text:      post.Text,
+		WrittenAt: writtenAt,
+	})
+
+	for _, revision := range revisions {
+		data = append(data, dto.PostRevisionResponse{
+			Version:   revision.Version,
+			Text:      revision.Text,
+			WrittenAt: revision.WrittenAt,
+		})
+	}
+
+	return dto.PostHistoryResponse{
+		PostID:    post.ID,
+		EditCount: post.EditCount,
+		Revisions: data,
 	}, nil
 }
 
-func (s *postService) GetAllPosts(ctx context.Context, req dto.PaginationRequest) (dto.PostPaginationResponse, error) {
-	dataWithPaginate, err := s.postRepo.GetAllPostsWithPagination(ctx, nil, req)
+func (s *postService) GetAllPosts(ctx context.Context, viewerId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error) {
+	dataWithPaginate, err := s.postRepo.GetAllPostsWithPagination(ctx, nil, viewerId, req)
 	if err != nil {
 		return dto.PostPaginationResponse{}, err
 	}
 
 	var data []dto.PostResponse
 	for _, post := range dataWithPaginate.Posts {
-		datum := dto.PostResponse{
-			ID:         post.ID,
-			Text:       post.Text,
-			TotalLikes: post.TotalLikes,
-			IsDeleted:  post.DeletedAt.Valid,
-			ParentID:   post.ParentID,
-			User: dto.UserResponse{
-				ID:       post.UserID.String(),
-				Name:     post.User.Name,
-				Bio:      post.User.Bio,
-				UserName: post.User.Username,
-				ImageUrl: post.User.ImageUrl,
-			},
-		}
+		data = append(data, toPostResponse(s.storage, post))
+	}
 
-		data = append(data, datum)
+	if err := markBookmarked(ctx, s.bookmarkRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
 	}
 
 	return dto.PostPaginationResponse{
@@ -227,3 +424,416 @@ func (s *postService) GetAllPosts(ctx context.Context, req dto.PaginationRequest
 		},
 	}, nil
 }
+
+func (s *postService) GetTimeline(ctx context.Context, userId string, req dto.TimelinePaginationRequest) (dto.TimelinePaginationResponse, error) {
+	dataWithPaginate, err := s.timelineRepo.GetTimelineWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.TimelinePaginationResponse{}, dto.ErrGetTimeline
+	}
+
+	data := make([]dto.PostResponse, 0, len(dataWithPaginate.Posts))
+	for _, post := range dataWithPaginate.Posts {
+		data = append(data, toPostResponse(s.storage, post))
+	}
+
+	if err := markBookmarked(ctx, s.bookmarkRepo, userId, data); err != nil {
+		return dto.TimelinePaginationResponse{}, dto.ErrGetTimeline
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, userId, data); err != nil {
+		return dto.TimelinePaginationResponse{}, dto.ErrGetTimeline
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, userId, data); err != nil {
+		return dto.TimelinePaginationResponse{}, dto.ErrGetTimeline
+	}
+
+	return dto.TimelinePaginationResponse{
+		Data: data,
+		TimelineMetaResponse: dto.TimelineMetaResponse{
+			PaginationResponse: dto.PaginationResponse{
+				Page:    dataWithPaginate.Page,
+				PerPage: dataWithPaginate.PerPage,
+				MaxPage: dataWithPaginate.MaxPage,
+				Count:   dataWithPaginate.Count,
+			},
+			Cursor: dataWithPaginate.Cursor,
+		},
+	}, nil
+}
+
+func (s *postService) RepostPostById(ctx context.Context, postId uint64, userId string) error {
+	if err := s.checkVerified(ctx, userId); err != nil {
+		return err
+	}
+
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
+	if err != nil {
+		return dto.ErrGetPostById
+	}
+
+	post, ok := originalPost(post)
+	if !ok {
+		return dto.ErrGetPostById
+	}
+	postId = post.ID
+
+	if _, err := s.postRepo.GetRepostByUserId(ctx, nil, postId, userId); err == nil {
+		return dto.ErrAlreadyReposted
+	}
+
+	repost := entity.Post{
+		UserID:     uuid.MustParse(userId),
+		RepostOfID: &postId,
+	}
+
+	result, err := s.postRepo.CreatePost(ctx, nil, repost)
+	if err != nil {
+		return dto.ErrRepostPostById
+	}
+
+	if err := s.timelineRepo.AddPostToTimelines(ctx, nil, result); err != nil {
+		return dto.ErrRepostPostById
+	}
+
+	if err := s.postRepo.UpdateRepostsCount(ctx, nil, postId, 1); err != nil {
+		return dto.ErrRepostPostById
+	}
+
+	return nil
+}
+
+func (s *postService) UnrepostPostById(ctx context.Context, postId uint64, userId string) error {
+	postId = originalPostId(ctx, s.postRepo, postId)
+
+	repost, err := s.postRepo.GetRepostByUserId(ctx, nil, postId, userId)
+	if err != nil {
+		return dto.ErrCheckRepostedPost
+	}
+
+	return s.unrepost(ctx, repost)
+}
+
+// unrepost removes a repost and takes it out of the repost count of the
+// original post
+func (s *postService) unrepost(ctx context.Context, repost entity.Post) error {
+	if err := s.timelineRepo.RemovePostFromTimelines(ctx, nil, repost.ID); err != nil {
+		return dto.ErrUnrepostPostById
+	}
+
+	if err := s.postRepo.DeleteRepostById(ctx, nil, repost.ID); err != nil {
+		return dto.ErrUnrepostPostById
+	}
+
+	if err := s.postRepo.UpdateRepostsCount(ctx, nil, *repost.RepostOfID, -1); err != nil {
+		return dto.ErrUnrepostPostById
+	}
+
+	return nil
+}
+
+// VotePoll counts the vote of the user and returns the poll with its
+// results, which the user gets to see from now on.
+func (s *postService) VotePoll(ctx context.Context, userId string, postId uint64, req dto.PollVoteRequest) (dto.PollResponse, error) {
+	post, err := s.postRepo.GetPostById(ctx, nil, postId)
+	if err != nil {
+		return dto.PollResponse{}, dto.ErrGetPostById
+	}
+
+	post, ok := originalPost(post)
+	if !ok {
+		return dto.PollResponse{}, dto.ErrGetPostById
+	}
+
+	if post.Poll == nil {
+		return dto.PollResponse{}, dto.ErrPollNotFound
+	}
+
+	blocked, err := s.blockRepo.CheckBlockedBetween(ctx, nil, userId, post.UserID.String())
+	if err != nil {
+		return dto.PollResponse{}, dto.ErrCheckBlocked
+	}
+
+	if blocked {
+		return dto.PollResponse{}, dto.ErrBlocked
+	}
+
+	if !post.Poll.ClosesAt.After(time.Now()) {
+		return dto.PollResponse{}, dto.ErrPollClosed
+	}
+
+	found := false
+	for _, option := range post.Poll.Options {
+		if option.ID.String() == req.OptionID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return dto.PollResponse{}, dto.ErrPollOptionInvalid
+	}
+
+	voted, err := s.pollRepo.Vote(ctx, nil, post.Poll.ID.String(), req.OptionID, userId)
+	if err != nil {
+		return dto.PollResponse{}, dto.ErrVotePoll
+	}
+
+	if !voted {
+		return dto.PollResponse{}, dto.ErrAlreadyVoted
+	}
+
+	poll, err := s.pollRepo.GetPollByPostId(ctx, nil, post.ID)
+	if err != nil {
+		return dto.PollResponse{}, dto.ErrVotePoll
+	}
+
+	response := toPollResponse(poll, true)
+	response.VotedOptionID = &req.OptionID
+	return response, nil
+}
+
+// saveMentions replaces the mentions of a post with the @usernames found in
+// its text, usernames that don't exist are left as plain text.
+func (s *postService) saveMentions(ctx context.Context, post entity.Post) ([]entity.Mention, error) {
+	if err := s.mentionRepo.DeleteMentionsByPostId(ctx, nil, post.ID); err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*entity.User)
+	mentions := make([]entity.Mention, 0)
+	for _, mention := range utils.ExtractMentions(post.Text) {
+		user, checked := users[mention.Value]
+		if !checked {
+			if found, flag, err := s.userRepo.CheckUsername(ctx, nil, mention.Value); err == nil && flag {
+				user = &found
+			}
+			users[mention.Value] = user
+		}
+
+		if user == nil {
+			continue
+		}
+
+		mentions = append(mentions, entity.Mention{
+			PostID:     post.ID,
+			StartIndex: mention.Start,
+			EndIndex:   mention.End,
+			UserID:     user.ID,
+			User:       *user,
+		})
+	}
+
+	if err := s.mentionRepo.CreateMentions(ctx, nil, mentions); err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// saveHashtags links the post to the normalized #tags found in its text
+func (s *postService) saveHashtags(ctx context.Context, post entity.Post) error {
+	seen := make(map[string]bool)
+	hashtagIds := make([]uint64, 0)
+	for _, tag := range utils.ExtractHashtags(post.Text) {
+		name := normalizeHashtag(tag.Value)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		hashtag, err := s.hashtagRepo.FirstOrCreateHashtag(ctx, nil, name)
+		if err != nil {
+			return err
+		}
+
+		hashtagIds = append(hashtagIds, hashtag.ID)
+	}
+
+	return s.hashtagRepo.SetPostHashtags(ctx, nil, post.ID, hashtagIds)
+}
+
+// notifyMentions notifies users newly mentioned in the post and retracts the
+// notification of users no longer mentioned after an edit. Failures are only
+// logged, the post itself is already saved.
+func (s *postService) notifyMentions(ctx context.Context, post entity.Post, previous []entity.Mention) {
+	mentioned := make(map[string]bool)
+	for _, mention := range previous {
+		mentioned[mention.UserID.String()] = true
+	}
+
+	current := make(map[string]bool)
+	for _, mention := range post.Mentions {
+		userId := mention.UserID.String()
+		if current[userId] {
+			continue
+		}
+		current[userId] = true
+
+		if mentioned[userId] {
+			continue
+		}
+
+		if err := s.notificationService.Notify(ctx, constants.ENUM_NOTIFICATION_MENTION, post.UserID.String(), userId, &post.ID); err != nil {
+			log.Println(err)
+		}
+	}
+
+	for userId := range mentioned {
+		if current[userId] {
+			continue
+		}
+
+		if err := s.notificationService.Retract(ctx, constants.ENUM_NOTIFICATION_MENTION, post.UserID.String(), userId, &post.ID); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// saveMedia checks every file against the media limits before storing any of
+// them, the type comes from the content and never from the file name.
+func (s *postService) saveMedia(ctx context.Context, files []*multipart.FileHeader, altTexts []string) ([]entity.PostAttachment, error) {
+	if len(altTexts) > len(files) {
+		return nil, dto.ErrTooManyAltTexts
+	}
+
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	if len(files) > constants.ENUM_MEDIA_MAX_IMAGES {
+		return nil, dto.ErrTooManyMedia
+	}
+
+	attachments := make([]entity.PostAttachment, 0, len(files))
+	for i, file := range files {
+		info, err := detectMedia(file)
+		if err != nil {
+			return nil, err
+		}
+
+		maxSize := s.mediaConfig.MaxImageSize
+		switch info.Type {
+		case constants.ENUM_MEDIA_GIF:
+			maxSize = s.mediaConfig.MaxGIFSize
+		case constants.ENUM_MEDIA_VIDEO:
+			maxSize = s.mediaConfig.MaxVideoSize
+		}
+
+		if file.Size > maxSize {
+			return nil, dto.ErrMediaTooLarge
+		}
+
+		if info.Type != constants.ENUM_MEDIA_IMAGE && len(files) > 1 {
+			return nil, dto.ErrTooManyMedia
+		}
+
+		attachment := entity.PostAttachment{
+			ID:       uuid.New(),
+			Position: i,
+			Type:     info.Type,
+			MimeType: info.MimeType,
+			Width:    info.Width,
+			Height:   info.Height,
+			Size:     file.Size,
+		}
+		attachment.Path = fmt.Sprintf("media/%s.%s", attachment.ID, info.Extension)
+
+		if i < len(altTexts) {
+			altText := strings.TrimSpace(altTexts[i])
+			if utf8.RuneCountInString(altText) > constants.ENUM_MEDIA_ALT_TEXT_MAX_LENGTH {
+				return nil, dto.ErrAltTextTooLong
+			}
+
+			if altText != "" {
+				attachment.AltText = &altText
+			}
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	for i, attachment := range attachments {
+		if err := utils.PutFile(ctx, s.storage, attachment.Path, files[i], attachment.MimeType); err != nil {
+			s.deleteMedia(ctx, attachments[:i])
+			return nil, dto.ErrUploadMedia
+		}
+	}
+
+	return attachments, nil
+}
+
+// newPoll builds the poll of a new post, nil when no options were given
+func newPoll(options []string, durationMinutes int) (*entity.Poll, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	if len(options) < constants.ENUM_POLL_MIN_OPTIONS || len(options) > constants.ENUM_POLL_MAX_OPTIONS {
+		return nil, dto.ErrPollOptions
+	}
+
+	if durationMinutes < constants.ENUM_POLL_MIN_DURATION_MINUTES || durationMinutes > constants.ENUM_POLL_MAX_DURATION_MINUTES {
+		return nil, dto.ErrPollDuration
+	}
+
+	poll := &entity.Poll{
+		ClosesAt: time.Now().Add(time.Duration(durationMinutes) * time.Minute),
+		Options:  make([]entity.PollOption, 0, len(options)),
+	}
+
+	for i, option := range options {
+		text := strings.TrimSpace(option)
+		if text == "" || utf8.RuneCountInString(text) > constants.ENUM_POLL_OPTION_MAX_LENGTH {
+			return nil, dto.ErrPollOptionText
+		}
+
+		poll.Options = append(poll.Options, entity.PollOption{
+			Position: i,
+			Text:     text,
+		})
+	}
+
+	return poll, nil
+}
+
+func detectMedia(file *multipart.FileHeader) (utils.MediaInfo, error) {
+	content, err := file.Open()
+	if err != nil {
+		return utils.MediaInfo{}, dto.ErrUploadMedia
+	}
+	defer content.Close()
+
+	info, err := utils.DetectMedia(content, file.Size)
+	if errors.Is(err, utils.ErrUnsupportedMedia) || errors.Is(err, utils.ErrInvalidMedia) {
+		return utils.MediaInfo{}, dto.ErrUnsupportedMedia
+	}
+	if err != nil {
+		return utils.MediaInfo{}, dto.ErrUploadMedia
+	}
+
+	return info, nil
+}
+
+// deleteMedia cleans up files of a post that couldn't be created
+func (s *postService) deleteMedia(ctx context.Context, attachments []entity.PostAttachment) {
+	for _, attachment := range attachments {
+		if err := s.storage.Delete(ctx, attachment.Path); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// checkVerified keeps accounts that haven't confirmed their email from posting
+func (s *postService) checkVerified(ctx context.Context, userId string) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.EmailVerifiedAt == nil {
+		return dto.ErrEmailNotVerified
+	}
+
+	return nil
+}
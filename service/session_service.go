@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	SessionService interface {
+		CreateSession(ctx context.Context, userId string, meta dto.SessionMetadata) (dto.UserLoginResponse, error)
+		Refresh(ctx context.Context, req dto.RefreshTokenRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error)
+		Logout(ctx context.Context, sessionId string) error
+		LogoutAll(ctx context.Context, userId string) error
+		LogoutOthers(ctx context.Context, userId string, sessionId string) error
+		GetSessions(ctx context.Context, userId string, currentSessionId string) ([]dto.SessionResponse, error)
+		RevokeSession(ctx context.Context, userId string, sessionId string) error
+	}
+
+	sessionService struct {
+		userRepo      repository.UserRepository
+		sessionRepo   repository.SessionRepository
+		sessionConfig config.SessionConfig
+		jwtService    JWTService
+	}
+)
+
+func NewSessionService(userRepo repository.UserRepository, sessionRepo repository.SessionRepository, sessionConfig config.SessionConfig, jwtService JWTService) SessionService {
+	return &sessionService{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		sessionConfig: sessionConfig,
+		jwtService:    jwtService,
+	}
+}
+
+// CreateSession signs a device in and hands out its first token pair
+func (s *sessionService) CreateSession(ctx context.Context, userId string, meta dto.SessionMetadata) (dto.UserLoginResponse, error) {
+	now := time.Now()
+	session := entity.Session{
+		UserID:     uuid.MustParse(userId),
+		UserAgent:  meta.UserAgent,
+		IPAddress:  meta.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.sessionConfig.RefreshTokenTTL),
+	}
+
+	result, err := s.sessionRepo.CreateSession(ctx, nil, session)
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrCreateSession
+	}
+
+	return s.issueTokens(ctx, userId, result.ID.String())
+}
+
+// Refresh rotates the refresh token. A token is only accepted once, seeing it
+// again means it leaked, so the whole session is revoked.
+func (s *sessionService) Refresh(ctx context.Context, req dto.RefreshTokenRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error) {
+	token, err := s.sessionRepo.GetRefreshTokenByHash(ctx, nil, helpers.HashToken(req.RefreshToken))
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrInvalidRefreshToken
+	}
+
+	session := token.Session
+	sessionId := session.ID.String()
+	if session.RevokedAt != nil || session.ExpiresAt.Before(time.Now()) {
+		return dto.UserLoginResponse{}, dto.ErrSessionRevoked
+	}
+
+	fresh, err := s.sessionRepo.MarkRefreshTokenUsed(ctx, nil, token.ID)
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrInvalidRefreshToken
+	}
+
+	if !fresh {
+		if err := s.sessionRepo.RevokeSession(ctx, nil, sessionId); err != nil {
+			return dto.UserLoginResponse{}, dto.ErrRevokeSession
+		}
+
+		return dto.UserLoginResponse{}, dto.ErrRefreshTokenReused
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return dto.UserLoginResponse{}, dto.ErrInvalidRefreshToken
+	}
+
+	if err := s.sessionRepo.TouchSession(ctx, nil, sessionId, meta, time.Now().Add(s.sessionConfig.RefreshTokenTTL)); err != nil {
+		return dto.UserLoginResponse{}, dto.ErrCreateSession
+	}
+
+	return s.issueTokens(ctx, session.UserID.String(), sessionId)
+}
+
+func (s *sessionService) Logout(ctx context.Context, sessionId string) error {
+	if err := s.sessionRepo.RevokeSession(ctx, nil, sessionId); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+func (s *sessionService) LogoutAll(ctx context.Context, userId string) error {
+	if err := s.sessionRepo.RevokeSessionsByUserId(ctx, nil, userId); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+// LogoutOthers signs out every device except the one making the request
+func (s *sessionService) LogoutOthers(ctx context.Context, userId string, sessionId string) error {
+	if err := s.sessionRepo.RevokeOtherSessions(ctx, nil, userId, sessionId); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+func (s *sessionService) GetSessions(ctx context.Context, userId string, currentSessionId string) ([]dto.SessionResponse, error) {
+	sessions, err := s.sessionRepo.GetActiveSessionsByUserId(ctx, nil, userId)
+	if err != nil {
+		return nil, dto.ErrGetSessions
+	}
+
+	data := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, dto.SessionResponse{
+			ID:         session.ID.String(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			CreatedAt:  session.CreatedAt,
+			IsCurrent:  session.ID.String() == currentSessionId,
+		})
+	}
+
+	return data, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, userId string, sessionId string) error {
+	if _, err := uuid.Parse(sessionId); err != nil {
+		return dto.ErrSessionNotFound
+	}
+
+	session, err := s.sessionRepo.GetSessionById(ctx, nil, sessionId)
+	if err != nil || session.UserID.String() != userId {
+		return dto.ErrSessionNotFound
+	}
+
+	if err := s.sessionRepo.RevokeSession(ctx, nil, sessionId); err != nil {
+		return dto.ErrRevokeSession
+	}
+
+	return nil
+}
+
+// issueTokens reads the role from the user on every refresh, so a role
+// change or suspension applies once the current access token expires.
+func (s *sessionService) issueTokens(ctx context.Context, userId string, sessionId string) (dto.UserLoginResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrGetUserById
+	}
+
+	if user.SuspendedAt != nil {
+		return dto.UserLoginResponse{}, dto.ErrUserSuspended
+	}
+
+	accessToken, err := s.jwtService.GenerateToken(userId, sessionId, user.Role)
+	if err != nil {
+		return dto.UserLoginResponse{}, err
+	}
+
+	refreshToken, err := helpers.GenerateToken()
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrCreateSession
+	}
+
+	token := entity.RefreshToken{
+		TokenHash: helpers.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.sessionConfig.RefreshTokenTTL),
+		SessionID: uuid.MustParse(sessionId),
+	}
+
+	if err := s.sessionRepo.CreateRefreshToken(ctx, nil, token); err != nil {
+		return dto.UserLoginResponse{}, dto.ErrCreateSession
+	}
+
+	return dto.UserLoginResponse{
+		Token:        accessToken,
+		ExpiresAt:    time.Now().Add(s.sessionConfig.AccessTokenTTL),
+		RefreshToken: refreshToken,
+		SessionID:    sessionId,
+	}, nil
+}
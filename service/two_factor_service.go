@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+)
+
+// prefix of challenge payloads, keeps them apart from other signed tokens
+const twoFactorChallengePrefix = "2fa:"
+
+type (
+	TwoFactorService interface {
+		Setup(ctx context.Context, userId string) (dto.TwoFactorSetupResponse, error)
+		Confirm(ctx context.Context, userId string, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error)
+		Disable(ctx context.Context, userId string, req dto.TwoFactorDisableRequest) error
+		IssueChallenge(ctx context.Context, userId string) (dto.UserLoginResponse, error)
+		Login(ctx context.Context, req dto.TwoFactorLoginRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error)
+	}
+
+	twoFactorService struct {
+		userRepo         repository.UserRepository
+		recoveryCodeRepo repository.RecoveryCodeRepository
+		challengeRepo    repository.TwoFactorChallengeRepository
+		sessionService   SessionService
+		twoFactorConfig  config.TwoFactorConfig
+		lockoutConfig    config.LoginLockoutConfig
+	}
+)
+
+func NewTwoFactorService(userRepo repository.UserRepository, recoveryCodeRepo repository.RecoveryCodeRepository, challengeRepo repository.TwoFactorChallengeRepository, sessionService SessionService, twoFactorConfig config.TwoFactorConfig, lockoutConfig config.LoginLockoutConfig) TwoFactorService {
+	return &twoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		challengeRepo:    challengeRepo,
+		sessionService:   sessionService,
+		twoFactorConfig:  twoFactorConfig,
+		lockoutConfig:    lockoutConfig,
+	}
+}
+
+// Setup stores a new pending secret, it only guards logins after Confirm.
+// Calling it again before confirming replaces the secret.
+func (s *twoFactorService) Setup(ctx context.Context, userId string) (dto.TwoFactorSetupResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, dto.ErrGetUserById
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return dto.TwoFactorSetupResponse{}, dto.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	encrypted, err := utils.AESEncryptWithKey(s.twoFactorConfig.EncryptionKey, secret)
+	if err != nil {
+		return dto.TwoFactorSetupResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	if err := s.userRepo.UpdateTwoFactor(ctx, nil, userId, &encrypted, nil); err != nil {
+		return dto.TwoFactorSetupResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	return dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: helpers.TOTPProvisioningURI(s.twoFactorConfig.Issuer, user.Username, secret),
+	}, nil
+}
+
+// Confirm enables two factor authentication once the app produces a valid
+// code, the recovery codes are only ever returned here.
+func (s *twoFactorService) Confirm(ctx context.Context, userId string, req dto.TwoFactorCodeRequest) (dto.RecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrGetUserById
+	}
+
+	if user.TwoFactorEnabledAt != nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrTwoFactorAlreadyEnabled
+	}
+
+	if user.TwoFactorSecret == nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrTwoFactorNotSetup
+	}
+
+	secret, err := utils.AESDecryptWithKey(s.twoFactorConfig.EncryptionKey, *user.TwoFactorSecret)
+	if err != nil || secret == "" {
+		return dto.RecoveryCodesResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	step, valid := helpers.ValidateTOTP(secret, req.Code, time.Now())
+	if !valid {
+		return dto.RecoveryCodesResponse{}, dto.ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, 0, constants.ENUM_TWO_FACTOR_RECOVERY_CODES)
+	hashes := make([]string, 0, constants.ENUM_TWO_FACTOR_RECOVERY_CODES)
+	for range constants.ENUM_TWO_FACTOR_RECOVERY_CODES {
+		code, err := helpers.GenerateRecoveryCode()
+		if err != nil {
+			return dto.RecoveryCodesResponse{}, dto.ErrSetupTwoFactor
+		}
+
+		codes = append(codes, code)
+		hashes = append(hashes, helpers.HashToken(code))
+	}
+
+	if err := s.recoveryCodeRepo.ReplaceRecoveryCodes(ctx, nil, userId, hashes); err != nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	now := time.Now()
+	if err := s.userRepo.UpdateTwoFactor(ctx, nil, userId, user.TwoFactorSecret, &now); err != nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	// the confirming code can't be replayed on the next login
+	if _, err := s.userRepo.MarkTOTPStepUsed(ctx, nil, userId, step); err != nil {
+		return dto.RecoveryCodesResponse{}, dto.ErrSetupTwoFactor
+	}
+
+	return dto.RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, userId string, req dto.TwoFactorDisableRequest) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.TwoFactorEnabledAt == nil {
+		return dto.ErrTwoFactorNotEnabled
+	}
+
+	if user.PasswordUnset {
+		return dto.ErrPasswordNotSet
+	}
+
+	checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.Password))
+	if err != nil || !checkPassword {
+		return dto.ErrPasswordNotMatch
+	}
+
+	if err := s.checkCode(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateTwoFactor(ctx, nil, userId, nil, nil); err != nil {
+		return dto.ErrSetupTwoFactor
+	}
+
+	if err := s.recoveryCodeRepo.DeleteRecoveryCodes(ctx, nil, userId); err != nil {
+		return dto.ErrSetupTwoFactor
+	}
+
+	return nil
+}
+
+// IssueChallenge answers a correct password on an account with two factor
+// authentication, the challenge only proves the password step.
+func (s *twoFactorService) IssueChallenge(ctx context.Context, userId string) (dto.UserLoginResponse, error) {
+	expiresAt := time.Now().Add(s.twoFactorConfig.ChallengeTTL)
+
+	challenge, err := s.challengeRepo.CreateChallenge(ctx, nil, entity.TwoFactorChallenge{
+		UserID:    uuid.MustParse(userId),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return dto.UserLoginResponse{}, dto.ErrIssueChallenge
+	}
+
+	return dto.UserLoginResponse{
+		ExpiresAt:         expiresAt,
+		TwoFactorRequired: true,
+		ChallengeToken:    helpers.SignToken(s.twoFactorConfig.Secret, twoFactorChallengePrefix+challenge.ID.String()+"|"+userId, expiresAt),
+	}, nil
+}
+
+// Login uses up the challenge whether or not the code is right, a wrong code
+// counts as a failed login and the password step has to be repeated.
+func (s *twoFactorService) Login(ctx context.Context, req dto.TwoFactorLoginRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error) {
+	payload, err := helpers.VerifySignedToken(s.twoFactorConfig.Secret, req.ChallengeToken)
+	if err != nil || !strings.HasPrefix(payload, twoFactorChallengePrefix) {
+		return dto.UserLoginResponse{}, dto.ErrInvalidChallenge
+	}
+
+	challengeId, userId, found := strings.Cut(strings.TrimPrefix(payload, twoFactorChallengePrefix), "|")
+	if _, err := uuid.Parse(challengeId); !found || err != nil {
+		return dto.UserLoginResponse{}, dto.ErrInvalidChallenge
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil || user.TwoFactorEnabledAt == nil {
+		return dto.UserLoginResponse{}, dto.ErrInvalidChallenge
+	}
+
+	if user.LockedUntil != nil && user.LockedUntil.After(time.Now()) {
+		return dto.UserLoginResponse{}, dto.ErrAccountLocked
+	}
+
+	used, err := s.challengeRepo.UseChallenge(ctx, nil, challengeId, userId, time.Now())
+	if err != nil || !used {
+		return dto.UserLoginResponse{}, dto.ErrInvalidChallenge
+	}
+
+	if err := s.checkCode(ctx, user, req.Code); err != nil {
+		recordFailedLogin(ctx, s.userRepo, s.lockoutConfig, userId)
+		return dto.UserLoginResponse{}, err
+	}
+
+	if user.FailedLoginAttempts > 0 {
+		if err := s.userRepo.ResetFailedLogins(ctx, nil, userId); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return s.sessionService.CreateSession(ctx, userId, meta)
+}
+
+// checkCode accepts a fresh code from the authenticator app or an unused
+// recovery code, either one only works once.
+func (s *twoFactorService) checkCode(ctx context.Context, user entity.User, code string) error {
+	if user.TwoFactorSecret == nil {
+		return dto.ErrTwoFactorNotEnabled
+	}
+
+	secret, err := utils.AESDecryptWithKey(s.twoFactorConfig.EncryptionKey, *user.TwoFactorSecret)
+	if err != nil || secret == "" {
+		return dto.ErrInvalidTwoFactorCode
+	}
+
+	if step, valid := helpers.ValidateTOTP(secret, code, time.Now()); valid {
+		fresh, err := s.userRepo.MarkTOTPStepUsed(ctx, nil, user.ID.String(), step)
+		if err != nil || !fresh {
+			return dto.ErrInvalidTwoFactorCode
+		}
+
+		return nil
+	}
+
+	used, err := s.recoveryCodeRepo.UseRecoveryCode(ctx, nil, user.ID.String(), helpers.HashToken(strings.ToLower(strings.TrimSpace(code))))
+	if err != nil || !used {
+		return dto.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
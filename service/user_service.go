@@ -1,9 +1,18 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
 	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
@@ -16,24 +25,49 @@ type (
 	UserService interface {
 		Register(ctx context.Context, req dto.UserCreateRequest) (dto.UserResponse, error)
 		GetUserById(ctx context.Context, userId string) (dto.UserResponse, error)
-		Verify(ctx context.Context, req dto.UserLoginRequest) (dto.UserLoginResponse, error)
+		Verify(ctx context.Context, req dto.UserLoginRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error)
 		GetUserByUsername(ctx context.Context, username string) (dto.UserResponse, error)
 		UpdateUser(ctx context.Context, userId string, req dto.UserProfileUpdateRequest) (dto.UserResponse, error)
-		GetUserPosts(ctx context.Context, username string, req dto.UserPostsPaginationRequest) (dto.PostPaginationResponse, error)
+		GetUserPosts(ctx context.Context, viewerId string, username string, req dto.UserPostsPaginationRequest) (dto.PostPaginationResponse, error)
+		GetMentions(ctx context.Context, userId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error)
+		VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error
+		ResendVerification(ctx context.Context, userId string) error
 	}
 
 	userService struct {
-		userRepo   repository.UserRepository
-		postRepo   repository.PostRepository
-		jwtService JWTService
+		userRepo           repository.UserRepository
+		postRepo           repository.PostRepository
+		bookmarkRepo       repository.BookmarkRepository
+		mentionRepo        repository.MentionRepository
+		pollRepo           repository.PollRepository
+		blockRepo          repository.BlockRepository
+		sessionService     SessionService
+		twoFactorService   TwoFactorService
+		mailer             utils.Mailer
+		verificationConfig config.VerificationConfig
+		lockoutConfig      config.LoginLockoutConfig
+		jwtService         JWTService
+		mediaConfig        config.MediaConfig
+		storage            utils.Storage
 	}
 )
 
-func NewUserService(userRepo repository.UserRepository, postRepo repository.PostRepository, jwtService JWTService) UserService {
+func NewUserService(userRepo repository.UserRepository, postRepo repository.PostRepository, bookmarkRepo repository.BookmarkRepository, mentionRepo repository.MentionRepository, pollRepo repository.PollRepository, blockRepo repository.BlockRepository, sessionService SessionService, twoFactorService TwoFactorService, mailer utils.Mailer, verificationConfig config.VerificationConfig, lockoutConfig config.LoginLockoutConfig, jwtService JWTService, mediaConfig config.MediaConfig, storage utils.Storage) UserService {
 	return &userService{
-		userRepo:   userRepo,
-		postRepo:   postRepo,
-		jwtService: jwtService,
+		userRepo:           userRepo,
+		postRepo:           postRepo,
+		bookmarkRepo:       bookmarkRepo,
+		mentionRepo:        mentionRepo,
+		pollRepo:           pollRepo,
+		blockRepo:          blockRepo,
+		sessionService:     sessionService,
+		twoFactorService:   twoFactorService,
+		mailer:             mailer,
+		verificationConfig: verificationConfig,
+		lockoutConfig:      lockoutConfig,
+		jwtService:         jwtService,
+		mediaConfig:        mediaConfig,
+		storage:            storage,
 	}
 }
 
@@ -43,9 +77,16 @@ func (s *userService) Register(ctx context.Context, req dto.UserCreateRequest) (
 		return dto.UserResponse{}, dto.ErrUsernameAlreadyExists
 	}
 
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	_, flag, _ = s.userRepo.CheckEmail(ctx, nil, email)
+	if flag {
+		return dto.UserResponse{}, dto.ErrEmailAlreadyExists
+	}
+
 	user := entity.User{
 		Name:     req.Name,
 		Username: req.UserName,
+		Email:    &email,
 		ImageUrl: nil,
 		Bio:      nil,
 		Password: req.Password,
@@ -56,13 +97,12 @@ func (s *userService) Register(ctx context.Context, req dto.UserCreateRequest) (
 		return dto.UserResponse{}, dto.ErrCreateUser
 	}
 
-	return dto.UserResponse{
-		ID:       userReg.ID.String(),
-		Name:     userReg.Name,
-		UserName: userReg.Username,
-		Bio:      userReg.Bio,
-		ImageUrl: userReg.ImageUrl,
-	}, nil
+	// the account exists either way, a failed email can be resent later
+	if err := s.sendVerification(ctx, userReg); err != nil {
+		log.Println(err)
+	}
+
+	return toUserResponse(s.storage, userReg), nil
 }
 
 func (s *userService) GetUserById(ctx context.Context, userId string) (dto.UserResponse, error) {
@@ -71,31 +111,42 @@ func (s *userService) GetUserById(ctx context.Context, userId string) (dto.UserR
 		return dto.UserResponse{}, dto.ErrGetUserById
 	}
 
-	return dto.UserResponse{
-		ID:       user.ID.String(),
-		Name:     user.Name,
-		UserName: user.Username,
-		Bio:      user.Bio,
-		ImageUrl: user.ImageUrl,
-	}, nil
+	return toUserResponse(s.storage, user), nil
 }
 
-func (s *userService) Verify(ctx context.Context, req dto.UserLoginRequest) (dto.UserLoginResponse, error) {
+func (s *userService) Verify(ctx context.Context, req dto.UserLoginRequest, meta dto.SessionMetadata) (dto.UserLoginResponse, error) {
 	check, flag, err := s.userRepo.CheckUsername(ctx, nil, req.UserName)
 	if err != nil || !flag {
 		return dto.UserLoginResponse{}, dto.ErrUsernameNotFound
 	}
 
+	if check.LockedUntil != nil && check.LockedUntil.After(time.Now()) {
+		return dto.UserLoginResponse{}, dto.ErrAccountLocked
+	}
+
 	checkPassword, err := helpers.CheckPassword(check.Password, []byte(req.Password))
 	if err != nil || !checkPassword {
+		recordFailedLogin(ctx, s.userRepo, s.lockoutConfig, check.ID.String())
 		return dto.UserLoginResponse{}, dto.ErrPasswordNotMatch
 	}
 
-	token := s.jwtService.GenerateToken(check.ID.String())
+	if check.SuspendedAt != nil {
+		return dto.UserLoginResponse{}, dto.ErrUserSuspended
+	}
 
-	return dto.UserLoginResponse{
-		Token: token,
-	}, nil
+	// failures are only forgotten once the second factor passed too, or a
+	// correct password would hand out fresh guesses at the code
+	if check.TwoFactorEnabledAt != nil {
+		return s.twoFactorService.IssueChallenge(ctx, check.ID.String())
+	}
+
+	if check.FailedLoginAttempts > 0 {
+		if err := s.userRepo.ResetFailedLogins(ctx, nil, check.ID.String()); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return s.sessionService.CreateSession(ctx, check.ID.String(), meta)
 }
 
 func (s *userService) GetUserByUsername(ctx context.Context, username string) (dto.UserResponse, error) {
@@ -104,13 +155,7 @@ func (s *userService) GetUserByUsername(ctx context.Context, username string) (d
 		return dto.UserResponse{}, dto.ErrUsernameNotFound
 	}
 
-	return dto.UserResponse{
-		ID:       user.ID.String(),
-		Name:     user.Name,
-		UserName: user.Username,
-		Bio:      user.Bio,
-		ImageUrl: user.ImageUrl,
-	}, nil
+	return toUserResponse(s.storage, user), nil
 }
 
 func (s *userService) UpdateUser(ctx context.Context, userId string, req dto.UserProfileUpdateRequest) (dto.UserResponse, error) {
@@ -123,19 +168,25 @@ func (s *userService) UpdateUser(ctx context.Context, userId string, req dto.Use
 		user.Name = req.Name
 	}
 
-	previousImage := user.ImageUrl
+	previousImage, previousBanner := user.ImageUrl, user.BannerUrl
 
 	if req.Image != nil {
-		var filenamePtr *string
-		imageId := uuid.New()
-		ext := utils.GetExtensions(req.Image.Filename)
+		key, err := s.saveImage(ctx, req.Image, "profile", utils.AvatarVariants)
+		if err != nil {
+			return dto.UserResponse{}, err
+		}
+		user.ImageUrl = &key
+	}
 
-		filename := fmt.Sprintf("profile/%s.%s", imageId, ext)
-		if err := utils.UploadFile(req.Image, filename); err != nil {
+	if req.Banner != nil {
+		key, err := s.saveImage(ctx, req.Banner, "banner", utils.BannerVariants)
+		if err != nil {
+			if req.Image != nil {
+				s.deleteImage(ctx, *user.ImageUrl, utils.AvatarVariants)
+			}
 			return dto.UserResponse{}, err
 		}
-		filenamePtr = &filename
-		user.ImageUrl = filenamePtr
+		user.BannerUrl = &key
 	}
 
 	if req.Bio != "" {
@@ -150,45 +201,84 @@ func (s *userService) UpdateUser(ctx context.Context, userId string, req dto.Use
 		return dto.UserResponse{}, dto.ErrCreateUser
 	}
 
-	if previousImage != nil {
-		if err := utils.DeleteFile(*previousImage); err != nil {
-			return dto.UserResponse{}, err
+	if req.Image != nil && previousImage != nil {
+		s.deleteImage(ctx, *previousImage, utils.AvatarVariants)
+	}
+
+	if req.Banner != nil && previousBanner != nil {
+		s.deleteImage(ctx, *previousBanner, utils.BannerVariants)
+	}
+
+	return toUserResponse(s.storage, userUpdate), nil
+}
+
+// saveImage stores every size of an uploaded profile image or banner below a
+// new key. The upload itself is never stored, so its metadata isn't either.
+func (s *userService) saveImage(ctx context.Context, file *multipart.FileHeader, prefix string, variants []utils.ImageVariant) (string, error) {
+	if file.Size > s.mediaConfig.MaxImageSize {
+		return "", dto.ErrMediaTooLarge
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return "", dto.ErrUploadMedia
+	}
+	defer content.Close()
+
+	images, err := utils.ProcessImage(content, variants, constants.ENUM_MEDIA_MAX_PROFILE_IMAGE_PIXELS)
+	if errors.Is(err, utils.ErrInvalidImage) {
+		return "", dto.ErrInvalidImage
+	}
+	if errors.Is(err, utils.ErrImageDimensions) {
+		return "", dto.ErrImageDimensions
+	}
+	if err != nil {
+		log.Println(err)
+		return "", dto.ErrUploadMedia
+	}
+
+	key := fmt.Sprintf("%s/%s", prefix, uuid.New())
+	for _, image := range images {
+		if err := s.storage.Put(ctx, utils.ImageVariantKey(key, image.Variant), bytes.NewReader(image.Data), int64(len(image.Data)), utils.ImageVariantMimeType); err != nil {
+			s.deleteImage(ctx, key, variants)
+			return "", dto.ErrUploadMedia
 		}
 	}
 
-	return dto.UserResponse{
-		ID:       userUpdate.ID.String(),
-		Name:     userUpdate.Name,
-		UserName: userUpdate.Username,
-		Bio:      userUpdate.Bio,
-		ImageUrl: userUpdate.ImageUrl,
-	}, nil
+	return key, nil
+}
+
+// deleteImage removes the sizes of a replaced or unused image, a file left
+// behind only takes up space so failures are logged.
+func (s *userService) deleteImage(ctx context.Context, key string, variants []utils.ImageVariant) {
+	for _, file := range utils.ImageVariantKeys(key, variants) {
+		if err := s.storage.Delete(ctx, file); err != nil {
+			log.Println(err)
+		}
+	}
 }
 
-func (s *userService) GetUserPosts(ctx context.Context, username string, req dto.UserPostsPaginationRequest) (dto.PostPaginationResponse, error) {
-	dataWithPaginate, err := s.postRepo.GetAllPostsWithPaginationByUsername(ctx, nil, username, req)
+func (s *userService) GetUserPosts(ctx context.Context, viewerId string, username string, req dto.UserPostsPaginationRequest) (dto.PostPaginationResponse, error) {
+	dataWithPaginate, err := s.postRepo.GetAllPostsWithPaginationByUsername(ctx, nil, viewerId, username, req)
 	if err != nil {
 		return dto.PostPaginationResponse{}, err
 	}
 
 	var data []dto.PostResponse
 	for _, post := range dataWithPaginate.Posts {
-		datum := dto.PostResponse{
-			ID:         post.ID,
-			Text:       post.Text,
-			TotalLikes: post.TotalLikes,
-			IsDeleted:  post.DeletedAt.Valid,
-			ParentID:   post.ParentID,
-			User: dto.UserResponse{
-				ID:       post.UserID.String(),
-				Name:     post.User.Name,
-				Bio:      post.User.Bio,
-				UserName: post.User.Username,
-				ImageUrl: post.User.ImageUrl,
-			},
-		}
+		data = append(data, toPostResponse(s.storage, post))
+	}
+
+	if err := markBookmarked(ctx, s.bookmarkRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
+	}
 
-		data = append(data, datum)
+	if err := markPollVotes(ctx, s.pollRepo, viewerId, data); err != nil {
+		return dto.PostPaginationResponse{}, err
 	}
 
 	return dto.PostPaginationResponse{
@@ -201,3 +291,113 @@ func (s *userService) GetUserPosts(ctx context.Context, username string, req dto
 		},
 	}, nil
 }
+
+func (s *userService) GetMentions(ctx context.Context, userId string, req dto.PaginationRequest) (dto.PostPaginationResponse, error) {
+	dataWithPaginate, err := s.mentionRepo.GetMentionsWithPagination(ctx, nil, userId, req)
+	if err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetMentions
+	}
+
+	data := make([]dto.PostResponse, 0, len(dataWithPaginate.Posts))
+	for _, post := range dataWithPaginate.Posts {
+		data = append(data, toPostResponse(s.storage, post))
+	}
+
+	if err := markBookmarked(ctx, s.bookmarkRepo, userId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetMentions
+	}
+
+	if err := hideQuotedPosts(ctx, s.blockRepo, userId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetMentions
+	}
+
+	if err := markPollVotes(ctx, s.pollRepo, userId, data); err != nil {
+		return dto.PostPaginationResponse{}, dto.ErrGetMentions
+	}
+
+	return dto.PostPaginationResponse{
+		Data: data,
+		PaginationResponse: dto.PaginationResponse{
+			Page:    dataWithPaginate.Page,
+			PerPage: dataWithPaginate.PerPage,
+			MaxPage: dataWithPaginate.MaxPage,
+			Count:   dataWithPaginate.Count,
+		},
+	}, nil
+}
+
+// VerifyEmail confirms the address a verification link was sent to, links
+// sent before an email change no longer match and are rejected.
+func (s *userService) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	payload, err := helpers.VerifySignedToken(s.verificationConfig.Secret, req.Token)
+	if err != nil {
+		return dto.ErrInvalidVerification
+	}
+
+	userId, email, found := strings.Cut(payload, "|")
+	if !found {
+		return dto.ErrInvalidVerification
+	}
+
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil || user.Email == nil || *user.Email != email {
+		return dto.ErrInvalidVerification
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return dto.ErrEmailAlreadyVerified
+	}
+
+	now := time.Now()
+	if _, err := s.userRepo.UpdateUser(ctx, nil, userId, entity.User{EmailVerifiedAt: &now}); err != nil {
+		return dto.ErrVerifyEmail
+	}
+
+	return nil
+}
+
+func (s *userService) ResendVerification(ctx context.Context, userId string) error {
+	user, err := s.userRepo.GetUserById(ctx, nil, userId)
+	if err != nil {
+		return dto.ErrGetUserById
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return dto.ErrEmailAlreadyVerified
+	}
+
+	return s.sendVerification(ctx, user)
+}
+
+func (s *userService) sendVerification(ctx context.Context, user entity.User) error {
+	if user.Email == nil {
+		return dto.ErrSendVerification
+	}
+
+	now := time.Now()
+	claimed, err := s.userRepo.MarkVerificationSent(ctx, nil, user.ID.String(), now, now.Add(-s.verificationConfig.ResendInterval))
+	if err != nil {
+		return dto.ErrSendVerification
+	}
+
+	if !claimed {
+		return dto.ErrVerificationThrottled
+	}
+
+	token := helpers.SignToken(s.verificationConfig.Secret, user.ID.String()+"|"+*user.Email, now.Add(s.verificationConfig.TTL))
+	link := fmt.Sprintf("%s/api/user/verify-email?token=%s", strings.TrimRight(s.verificationConfig.BaseURL, "/"), url.QueryEscape(token))
+
+	body, err := utils.ParseEmailTemplate("base_mail", map[string]string{
+		"Email":  *user.Email,
+		"Verify": link,
+	})
+	if err != nil {
+		return dto.ErrSendVerification
+	}
+
+	if err := s.mailer.Send(*user.Email, "Verify your account", body); err != nil {
+		return dto.ErrSendVerification
+	}
+
+	return nil
+}
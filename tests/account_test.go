@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func Test_PurgeUser(t *testing.T) {
+	statements := dryRunStatements(t, func(db *gorm.DB) error {
+		return repository.NewUserRepository(db).PurgeUser(context.Background(), nil, uuid.NewString())
+	})
+	sql := strings.Join(statements, "\n")
+
+	assert.Contains(t, statements[0], "SAVEPOINT")
+	for _, table := range []string{"likes", "follows", "timelines", "bookmarks", "notifications", "blocks", "mutes", "refresh_tokens", "sessions", "personal_access_tokens", "recovery_codes", "password_resets", "two_factor_challenges", "user_identities", "post_revisions", "post_attachments", "poll_votes", "poll_options", "polls"} {
+		assert.Contains(t, sql, "DELETE FROM "+table+" WHERE")
+	}
+
+	// counters are given back before the rows feeding them are deleted
+	assert.Less(t, strings.Index(sql, "SET total_likes = total_likes - 1"), strings.Index(sql, "DELETE FROM likes"))
+	assert.Less(t, strings.Index(sql, "SET total_followers = total_followers - 1"), strings.Index(sql, "DELETE FROM follows"))
+	assert.Less(t, strings.Index(sql, "SET total_votes = total_votes - 1"), strings.Index(sql, "DELETE FROM poll_votes"))
+
+	assert.Contains(t, statements[len(statements)-1], "UPDATE users SET name = 'Deleted user'")
+	assert.Contains(t, statements[len(statements)-1], "deleted_at = NOW()")
+}
+
+// purgeUserRepository hands out a batch of accounts due for purge
+type purgeUserRepository struct {
+	repository.UserRepository
+	users  []entity.User
+	purged *[]string
+}
+
+func (r purgeUserRepository) GetUsersDueForPurge(ctx context.Context, tx *gorm.DB, before time.Time, limit int) ([]entity.User, error) {
+	return r.users, nil
+}
+
+func (r purgeUserRepository) PurgeUser(ctx context.Context, tx *gorm.DB, userId string) error {
+	*r.purged = append(*r.purged, userId)
+	return nil
+}
+
+type purgeAttachmentRepository struct {
+	repository.PostAttachmentRepository
+}
+
+func (r purgeAttachmentRepository) GetAttachmentPathsByUserId(ctx context.Context, tx *gorm.DB, userId string) ([]string, error) {
+	return []string{"posts/" + userId + ".png"}, nil
+}
+
+// failingStorage fails every delete and remembers what was asked for
+type failingStorage struct {
+	utils.Storage
+	deleted *[]string
+}
+
+func (s failingStorage) Delete(ctx context.Context, key string) error {
+	*s.deleted = append(*s.deleted, key)
+	return errors.New("storage unavailable")
+}
+
+func Test_PurgeDeletedAccountsKeepsGoing(t *testing.T) {
+	users := []entity.User{{ID: uuid.New()}, {ID: uuid.New()}}
+	var purged, deleted []string
+
+	accountService := service.NewAccountService(purgeUserRepository{users: users, purged: &purged}, purgeAttachmentRepository{}, nil, failingStorage{deleted: &deleted}, config.AccountConfig{})
+
+	count, err := accountService.PurgeDeletedAccounts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, purged, 2)
+	assert.Len(t, deleted, 2)
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func authorizeRequest(role string) int {
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	server.GET("/", func(ctx *gin.Context) {
+		ctx.Set("role", role)
+		ctx.Next()
+	}, middleware.Authorize(constants.ENUM_ROLE_ADMIN), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder.Code
+}
+
+func Test_AuthorizeAllowsRole(t *testing.T) {
+	assert.Equal(t, http.StatusOK, authorizeRequest(constants.ENUM_ROLE_ADMIN))
+}
+
+func Test_AuthorizeRejectsOtherRoles(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, authorizeRequest(constants.ENUM_ROLE_USER))
+	assert.Equal(t, http.StatusForbidden, authorizeRequest(""))
+}
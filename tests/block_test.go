@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	return db
+}
+
+func Test_HideUsersFromViewer(t *testing.T) {
+	db := dryRunDB(t)
+	viewerId := "7f9c24e5-7b4c-4d35-9d4a-2d1d0f6d3c11"
+
+	stmt := db.Model(&entity.Post{}).Scopes(repository.HideUsersFromViewer(viewerId, true)).Find(&[]entity.Post{}).Statement
+	sql := stmt.SQL.String()
+
+	assert.Contains(t, sql, "posts.user_id NOT IN (SELECT blocked_id FROM blocks WHERE blocker_id = $1 UNION SELECT blocker_id FROM blocks WHERE blocked_id = $2 UNION SELECT muted_id FROM mutes WHERE muter_id = $3)")
+	assert.Contains(t, sql, "(posts.repost_of_id IS NULL OR posts.repost_of_id NOT IN")
+	assert.Len(t, stmt.Vars, 6)
+}
+
+func Test_HideUsersFromViewerKeepsMuted(t *testing.T) {
+	db := dryRunDB(t)
+
+	sql := db.Model(&entity.Post{}).Scopes(repository.HideUsersFromViewer("7f9c24e5-7b4c-4d35-9d4a-2d1d0f6d3c11", false)).Find(&[]entity.Post{}).Statement.SQL.String()
+
+	assert.Contains(t, sql, "blocks")
+	assert.NotContains(t, sql, "mutes")
+}
+
+func Test_HideUsersFromViewerAnonymous(t *testing.T) {
+	db := dryRunDB(t)
+
+	sql := db.Model(&entity.Post{}).Scopes(repository.HideUsersFromViewer("", true)).Find(&[]entity.Post{}).Statement.SQL.String()
+
+	assert.NotContains(t, sql, "blocks")
+}
+
+type blockedRepository struct {
+	repository.BlockRepository
+}
+
+func (r blockedRepository) CheckBlockedBetween(ctx context.Context, tx *gorm.DB, userId string, otherId string) (bool, error) {
+	return true, nil
+}
+
+func (r blockedRepository) GetHiddenUserIds(ctx context.Context, tx *gorm.DB, viewerId string, userIds []string) ([]string, error) {
+	return userIds, nil
+}
+
+// directConversationRepository serves one conversation between two users
+type directConversationRepository struct {
+	repository.ConversationRepository
+	conversation entity.Conversation
+}
+
+func (r directConversationRepository) GetConversationById(ctx context.Context, tx *gorm.DB, conversationId uint64) (entity.Conversation, error) {
+	return r.conversation, nil
+}
+
+func Test_QuoteBlockedAuthor(t *testing.T) {
+	quoted := entity.Post{ID: 1, Text: "hello", UserID: uuid.New()}
+	postService := newPostService(postServiceDeps{userRepo: verifiedUserRepository{}, postRepo: editPostRepository{post: quoted}, blockRepo: blockedRepository{}})
+
+	_, err := postService.CreatePost(context.Background(), uuid.New().String(), dto.PostCreateRequest{Text: "look", QuotedPostID: &quoted.ID})
+	assert.ErrorIs(t, err, dto.ErrBlocked)
+}
+
+func Test_MessageBlockedUser(t *testing.T) {
+	sender, recipient := uuid.New(), uuid.New()
+	conversation := entity.Conversation{ID: 1, Participants: []entity.ConversationParticipant{{UserID: sender}, {UserID: recipient}}}
+	conversationService := service.NewConversationService(nil, directConversationRepository{conversation: conversation}, nil, blockedRepository{}, nil, nil)
+
+	_, err := conversationService.SendMessage(context.Background(), sender.String(), conversation.ID, dto.MessageCreateRequest{Text: "hi"})
+	assert.ErrorIs(t, err, dto.ErrBlocked)
+}
+
+func Test_NotifySkipsBlockedActor(t *testing.T) {
+	notificationService := service.NewNotificationService(nil, blockedRepository{}, nil)
+
+	err := notificationService.Notify(context.Background(), constants.ENUM_NOTIFICATION_MENTION, uuid.NewString(), uuid.NewString(), nil)
+	assert.NoError(t, err)
+}
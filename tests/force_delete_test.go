@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunTx stands in for an open transaction, so Transaction nests through a
+// savepoint instead of dialing the database
+type dryRunTx struct {
+	gorm.ConnPool
+}
+
+func (dryRunTx) Commit() error   { return nil }
+func (dryRunTx) Rollback() error { return nil }
+
+// dryRunStatements runs fn on a dry run transaction and returns the SQL of
+// every raw statement it executed, with named arguments bound
+func dryRunStatements(t *testing.T, fn func(db *gorm.DB) error) []string {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: dryRunTx{}}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+
+	var statements []string
+	assert.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:statements", func(db *gorm.DB) {
+		statements = append(statements, db.Statement.SQL.String())
+	}))
+
+	assert.NoError(t, fn(db))
+	return statements
+}
+
+func Test_ForceDeletePost(t *testing.T) {
+	statements := dryRunStatements(t, func(db *gorm.DB) error {
+		return repository.NewPostRepository(db).ForceDeletePost(context.Background(), nil, 42)
+	})
+
+	assert.Contains(t, statements[0], "SAVEPOINT")
+	assert.Contains(t, strings.Join(statements, "\n"), "WHERE id = (SELECT repost_of_id FROM posts WHERE id = $1 AND deleted_at IS NULL) AND total_reposts > 0")
+	assert.Contains(t, statements, "DELETE FROM posts WHERE repost_of_id = $1")
+	assert.Contains(t, statements, "DELETE FROM post_attachments WHERE post_id = $1")
+	assert.Equal(t, "DELETE FROM posts WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM posts WHERE parent_id = $2 OR quoted_post_id = $3)", statements[len(statements)-1])
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/webp"
+)
+
+// testPhoto is a 200x100 JPEG, red on the left and blue on the right, with
+// an EXIF segment saying it is shown rotated 90° clockwise and a GPS position
+func testPhoto(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 100 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var encoded bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+
+	entry := func(tag, kind uint16, value uint32) []byte {
+		b := binary.BigEndian.AppendUint16(nil, tag)
+		b = binary.BigEndian.AppendUint16(b, kind)
+		b = binary.BigEndian.AppendUint32(b, 1)
+		return binary.BigEndian.AppendUint32(b, value)
+	}
+
+	// IFD0 holds the orientation and points at the GPS IFD right after it
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x02")
+	tiff = append(tiff, entry(0x0112, 3, 6<<16)...)
+	tiff = append(tiff, entry(0x8825, 4, 38)...)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 1)
+	tiff = append(tiff, entry(0x0001, 2, uint32('N')<<24)...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := encoded.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func Test_ProcessImage(t *testing.T) {
+	images, err := utils.ProcessImage(bytes.NewReader(testPhoto(t)), utils.AvatarVariants, 1_000_000)
+	assert.NoError(t, err)
+	assert.Len(t, images, len(utils.AvatarVariants))
+
+	for _, processed := range images {
+		assert.False(t, bytes.Contains(processed.Data, []byte("Exif")), "metadata kept in %s", processed.Variant.Name())
+
+		decoded, err := webp.Decode(bytes.NewReader(processed.Data))
+		assert.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, processed.Variant.Width, processed.Variant.Height), decoded.Bounds())
+
+		// rotated upright the red half is on top, the square crop keeps
+		// the middle with red above blue
+		size := processed.Variant.Width
+		top, _, _, _ := decoded.At(size/2, size/8).RGBA()
+		_, _, bottom, _ := decoded.At(size/2, size-1-size/8).RGBA()
+		assert.Greater(t, top>>8, uint32(200))
+		assert.Greater(t, bottom>>8, uint32(200))
+	}
+
+	banners, err := utils.ProcessImage(bytes.NewReader(testPhoto(t)), utils.BannerVariants, 1_000_000)
+	assert.NoError(t, err)
+	for _, processed := range banners {
+		decoded, err := webp.Decode(bytes.NewReader(processed.Data))
+		assert.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, processed.Variant.Width, processed.Variant.Height), decoded.Bounds())
+	}
+}
+
+func Test_ProcessImageRejects(t *testing.T) {
+	_, err := utils.ProcessImage(bytes.NewReader(testPhoto(t)), utils.AvatarVariants, 200*100-1)
+	assert.ErrorIs(t, err, utils.ErrImageDimensions)
+
+	_, err = utils.ProcessImage(bytes.NewReader([]byte("not an image")), utils.AvatarVariants, 1_000_000)
+	assert.ErrorIs(t, err, utils.ErrInvalidImage)
+
+	_, err = utils.ProcessImage(bytes.NewReader(testMP4(640, 480)), utils.AvatarVariants, 1_000_000)
+	assert.ErrorIs(t, err, utils.ErrInvalidImage)
+}
+
+func Test_ImageVariantKey(t *testing.T) {
+	assert.Equal(t, "profile/abc/128.webp", utils.ImageVariantKey("profile/abc", utils.AvatarVariants[1]))
+	assert.Equal(t, []string{"banner/abc/600.webp", "banner/abc/1500.webp"}, utils.ImageVariantKeys("banner/abc", utils.BannerVariants))
+
+	// images stored before sizes were generated keep their single file
+	assert.Equal(t, "profile/abc.png", utils.ImageVariantKey("profile/abc.png", utils.AvatarVariants[0]))
+	assert.Equal(t, []string{"profile/abc.png"}, utils.ImageVariantKeys("profile/abc.png", utils.AvatarVariants))
+}
+
+func Test_ProcessImageWebP(t *testing.T) {
+	// a noisy gradient in a size that isn't a multiple of the 16 pixel
+	// blocks, so every coefficient size and the padding get written
+	img := image.NewRGBA(image.Rect(0, 0, 33, 47))
+	for y := 0; y < 47; y++ {
+		for x := 0; x < 33; x++ {
+			noise := uint8((x*7 + y*13) % 40)
+			img.Set(x, y, color.RGBA{R: uint8(x*7) + noise, G: uint8(y*5) + noise, B: 255 - noise, A: 255})
+		}
+	}
+
+	var encoded bytes.Buffer
+	assert.NoError(t, png.Encode(&encoded, img))
+
+	variant := utils.ImageVariant{Width: 33, Height: 47}
+	images, err := utils.ProcessImage(&encoded, []utils.ImageVariant{variant}, 1_000_000)
+	assert.NoError(t, err)
+	assert.Equal(t, "RIFF", string(images[0].Data[:4]))
+
+	decoded, err := webp.Decode(bytes.NewReader(images[0].Data))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 33, 47), decoded.Bounds())
+
+	for _, p := range []image.Point{{0, 0}, {16, 20}, {32, 46}} {
+		want, _, _, _ := img.At(p.X, p.Y).RGBA()
+		got, _, _, _ := decoded.At(p.X, p.Y).RGBA()
+		assert.InDelta(t, want>>8, got>>8, 24, "red at %v", p)
+	}
+}
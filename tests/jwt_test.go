@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newJWTKeys(t *testing.T) (config.JWTKey, config.JWTKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	return config.JWTKey{ID: "rsa", Algorithm: constants.ENUM_JWT_ALG_RS256, PrivateKey: rsaKey},
+		config.JWTKey{ID: "ed", Algorithm: constants.ENUM_JWT_ALG_EDDSA, PrivateKey: edKey}
+}
+
+func Test_JWTSignsWithNewestActiveKey(t *testing.T) {
+	rsaKey, edKey := newJWTKeys(t)
+	rsaKey.ActivatesAt = time.Now().Add(-time.Hour)
+	edKey.ActivatesAt = time.Now().Add(time.Hour)
+
+	jwtService := service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "test", Keys: []config.JWTKey{rsaKey, edKey}}, time.Minute)
+	token, err := jwtService.GenerateToken("user", "session", constants.ENUM_ROLE_ADMIN)
+	assert.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "rsa", parsed.Header["kid"])
+	assert.Equal(t, constants.ENUM_JWT_ALG_RS256, parsed.Header["alg"])
+
+	userId, err := jwtService.GetUserIDByToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user", userId)
+
+	role, err := jwtService.GetRoleByToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ENUM_ROLE_ADMIN, role)
+}
+
+func Test_JWTRejectsUnknownKeyAndIssuer(t *testing.T) {
+	rsaKey, edKey := newJWTKeys(t)
+
+	signer := service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "test", Keys: []config.JWTKey{edKey}}, time.Minute)
+	token, err := signer.GenerateToken("user", "session", constants.ENUM_ROLE_USER)
+	assert.NoError(t, err)
+
+	_, err = service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "test", Keys: []config.JWTKey{rsaKey}}, time.Minute).GetUserIDByToken(token)
+	assert.Error(t, err)
+
+	_, err = service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "other", Keys: []config.JWTKey{edKey}}, time.Minute).GetUserIDByToken(token)
+	assert.Error(t, err)
+}
+
+func Test_JWTWithoutActiveKey(t *testing.T) {
+	_, edKey := newJWTKeys(t)
+	edKey.ActivatesAt = time.Now().Add(time.Hour)
+
+	token, err := service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "test", Keys: []config.JWTKey{edKey}}, time.Minute).GenerateToken("user", "session", constants.ENUM_ROLE_USER)
+	assert.ErrorIs(t, err, dto.ErrNoActiveSigningKey)
+	assert.Empty(t, token)
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "next.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	t.Setenv("JWT_KEYS", "next="+path+"@"+time.Now().Add(time.Hour).Format(time.RFC3339))
+	_, err = config.GetJWTConfig()
+	assert.ErrorIs(t, err, config.ErrNoActiveSigningKey)
+
+	t.Setenv("JWT_KEYS", "next="+path)
+	_, err = config.GetJWTConfig()
+	assert.NoError(t, err)
+}
+
+func Test_JWKSPublishesEveryKey(t *testing.T) {
+	rsaKey, edKey := newJWTKeys(t)
+	edKey.ActivatesAt = time.Now().Add(time.Hour)
+
+	jwks := service.NewJWTService(nil, nil, config.JWTConfig{Issuer: "test", Keys: []config.JWTKey{rsaKey, edKey}}, time.Minute).GetJWKS()
+	assert.Len(t, jwks.Keys, 2)
+
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.Len(t, jwks.Keys[1].X, 43)
+}
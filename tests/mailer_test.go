@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryMailer(t *testing.T) {
+	mailer := utils.NewMemoryMailer()
+
+	body, err := utils.ParseEmailTemplate("base_mail", map[string]string{
+		"Email":  "user@example.com",
+		"Verify": "http://localhost:8888/api/user/verify-email?token=abc",
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, mailer.Send("user@example.com", "Verify your account", body))
+
+	messages := mailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "user@example.com", messages[0].To)
+	assert.Contains(t, messages[0].Body, "verify-email?token=abc")
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mp4Box(boxType string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	box := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(box, boxType...), body...)
+}
+
+// testMP4 has its moov box after the media data, like most camera recordings
+func testMP4(width, height uint32) []byte {
+	ftyp := mp4Box("ftyp", []byte("isom\x00\x00\x02\x00isommp41"))
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:], width<<16)
+	binary.BigEndian.PutUint32(tkhd[80:], height<<16)
+	audio := mp4Box("trak", mp4Box("tkhd", make([]byte, 84)))
+	video := mp4Box("trak", mp4Box("tkhd", tkhd))
+
+	return bytes.Join([][]byte{ftyp, mp4Box("mdat", make([]byte, 1024)), mp4Box("moov", mp4Box("mvhd", make([]byte, 100)), audio, video)}, nil)
+}
+
+func Test_DetectMedia(t *testing.T) {
+	var pngFile bytes.Buffer
+	assert.NoError(t, png.Encode(&pngFile, image.NewRGBA(image.Rect(0, 0, 3, 2))))
+
+	// lossless WebP header of a 640x480 image
+	webp := []byte("RIFF\x00\x00\x00\x00WEBPVP8L\x00\x00\x00\x00\x2f")
+	webp = binary.LittleEndian.AppendUint32(webp, 639|479<<14)
+	webp = append(webp, make([]byte, 16)...)
+
+	tests := []struct {
+		name    string
+		content []byte
+		media   utils.MediaInfo
+	}{
+		{"png", pngFile.Bytes(), utils.MediaInfo{Type: constants.ENUM_MEDIA_IMAGE, MimeType: "image/png", Extension: "png", Width: 3, Height: 2}},
+		{"webp", webp, utils.MediaInfo{Type: constants.ENUM_MEDIA_IMAGE, MimeType: "image/webp", Extension: "webp", Width: 640, Height: 480}},
+		{"mp4", testMP4(1280, 720), utils.MediaInfo{Type: constants.ENUM_MEDIA_VIDEO, MimeType: "video/mp4", Extension: "mp4", Width: 1280, Height: 720}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			media, err := utils.DetectMedia(bytes.NewReader(test.content), int64(len(test.content)))
+			assert.NoError(t, err)
+			assert.Equal(t, test.media, media)
+		})
+	}
+}
+
+func Test_DetectMediaRejectsOtherContent(t *testing.T) {
+	pdf := []byte("%PDF-1.7 not an image.png")
+	_, err := utils.DetectMedia(bytes.NewReader(pdf), int64(len(pdf)))
+	assert.ErrorIs(t, err, utils.ErrUnsupportedMedia)
+
+	truncated := []byte("\x89PNG\r\n\x1a\n")
+	_, err = utils.DetectMedia(bytes.NewReader(truncated), int64(len(truncated)))
+	assert.ErrorIs(t, err, utils.ErrInvalidMedia)
+
+	noVideo := testMP4(0, 0)
+	_, err = utils.DetectMedia(bytes.NewReader(noVideo), int64(len(noVideo)))
+	assert.ErrorIs(t, err, utils.ErrInvalidMedia)
+}
+
+func Test_CreatePostMediaCount(t *testing.T) {
+	postService := newPostService(postServiceDeps{userRepo: verifiedUserRepository{}})
+	userId := uuid.New().String()
+
+	_, err := postService.CreatePost(context.Background(), userId, dto.PostCreateRequest{Text: "hello", AltText: []string{"a cat"}})
+	assert.ErrorIs(t, err, dto.ErrTooManyAltTexts)
+
+	_, err = postService.CreatePost(context.Background(), userId, dto.PostCreateRequest{Media: []*multipart.FileHeader{{}}, AltText: []string{"a cat", "a dog"}})
+	assert.ErrorIs(t, err, dto.ErrTooManyAltTexts)
+
+	_, err = postService.CreatePost(context.Background(), userId, dto.PostCreateRequest{Media: make([]*multipart.FileHeader, constants.ENUM_MEDIA_MAX_IMAGES+1)})
+	assert.ErrorIs(t, err, dto.ErrTooManyMedia)
+}
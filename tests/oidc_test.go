@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockOIDCServer is a minimal provider: codes are handed out by authorize
+// and redeemed once at the token endpoint if the PKCE verifier matches.
+type mockOIDCServer struct {
+	*httptest.Server
+	key   *rsa.PrivateKey
+	codes map[string]url.Values
+}
+
+func newMockOIDCServer(t *testing.T) *mockOIDCServer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	mock := &mockOIDCServer{key: key, codes: map[string]url.Values{}}
+	mux := http.NewServeMux()
+	mock.Server = httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 mock.URL,
+			"authorization_endpoint": mock.URL + "/authorize",
+			"token_endpoint":         mock.URL + "/token",
+			"jwks_uri":               mock.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "mock",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		authorization, ok := mock.codes[r.Form.Get("code")]
+		delete(mock.codes, r.Form.Get("code"))
+		if !ok || helpers.PKCEChallenge(r.Form.Get("code_verifier")) != authorization.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":                mock.URL,
+			"aud":                authorization.Get("client_id"),
+			"sub":                "mock-subject",
+			"email":              "Bot@Example.com",
+			"email_verified":     true,
+			"preferred_username": "bot",
+			"nonce":              authorization.Get("nonce"),
+			"exp":                time.Now().Add(time.Minute).Unix(),
+		})
+		token.Header["kid"] = "mock"
+		idToken, _ := token.SignedString(key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+	})
+
+	t.Cleanup(mock.Close)
+	return mock
+}
+
+// authorize plays the user signing in and returns the code of the redirect
+func (m *mockOIDCServer) authorize(t *testing.T, authorizationURL string) string {
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+	code, _ := helpers.GenerateToken()
+	m.codes[code] = parsed.Query()
+	return code
+}
+
+func newMockOIDCClient(mock *mockOIDCServer) *utils.OIDCClient {
+	return utils.NewOIDCClient(config.OIDCProviderConfig{
+		Name:        "mock",
+		Issuer:      mock.URL,
+		ClientID:    "twitter-clone",
+		RedirectURL: "http://localhost:3000/oidc/callback",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+}
+
+func Test_OIDCAuthorizationCodeWithPKCE(t *testing.T) {
+	mock := newMockOIDCServer(t)
+	client := newMockOIDCClient(mock)
+
+	verifier, _ := helpers.GenerateToken()
+	authorizationURL, err := client.AuthorizationURL(context.Background(), "state", "nonce", helpers.PKCEChallenge(verifier))
+	assert.NoError(t, err)
+
+	claims, err := client.Exchange(context.Background(), mock.authorize(t, authorizationURL), verifier, "nonce")
+	assert.NoError(t, err)
+	assert.Equal(t, "mock-subject", claims.Subject)
+	assert.Equal(t, "Bot@Example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "bot", claims.PreferredUsername)
+}
+
+func Test_OIDCRejectsWrongVerifierAndNonce(t *testing.T) {
+	mock := newMockOIDCServer(t)
+	client := newMockOIDCClient(mock)
+
+	verifier, _ := helpers.GenerateToken()
+	authorizationURL, err := client.AuthorizationURL(context.Background(), "state", "nonce", helpers.PKCEChallenge(verifier))
+	assert.NoError(t, err)
+
+	_, err = client.Exchange(context.Background(), mock.authorize(t, authorizationURL), "other verifier", "nonce")
+	assert.ErrorIs(t, err, utils.ErrOIDCExchange)
+
+	_, err = client.Exchange(context.Background(), mock.authorize(t, authorizationURL), verifier, "other nonce")
+	assert.ErrorIs(t, err, utils.ErrOIDCIDToken)
+}
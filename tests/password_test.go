@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// passwordUserRepository serves one user and keeps the password set on it
+type passwordUserRepository struct {
+	repository.UserRepository
+	user *entity.User
+}
+
+func (r passwordUserRepository) GetUserById(ctx context.Context, tx *gorm.DB, userId string) (entity.User, error) {
+	return *r.user, nil
+}
+
+func (r passwordUserRepository) UpdatePassword(ctx context.Context, tx *gorm.DB, userId string, password string) error {
+	r.user.Password = password
+	r.user.PasswordUnset = false
+	return nil
+}
+
+type passwordResetRepository struct {
+	repository.PasswordResetRepository
+}
+
+func (r passwordResetRepository) InvalidatePasswordResets(ctx context.Context, tx *gorm.DB, userId string) error {
+	return nil
+}
+
+type signedInSessionService struct {
+	service.SessionService
+}
+
+func (s signedInSessionService) LogoutOthers(ctx context.Context, userId string, sessionId string) error {
+	return nil
+}
+
+func Test_ChangePasswordSetsFirstPassword(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Password: "unknown", PasswordUnset: true}
+	passwordService := service.NewPasswordService(passwordUserRepository{user: user}, passwordResetRepository{}, signedInSessionService{}, nil, config.PasswordResetConfig{})
+	accountService := service.NewAccountService(passwordUserRepository{user: user}, nil, nil, nil, config.AccountConfig{})
+
+	_, err := accountService.DeleteAccount(context.Background(), user.ID.String(), dto.AccountDeleteRequest{Password: "unknown"})
+	assert.ErrorIs(t, err, dto.ErrPasswordNotSet)
+
+	assert.NoError(t, passwordService.ChangePassword(context.Background(), user.ID.String(), uuid.NewString(), dto.ChangePasswordRequest{NewPassword: "first password"}))
+	assert.False(t, user.PasswordUnset)
+
+	ok, err := helpers.CheckPassword(user.Password, []byte("first password"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	err = passwordService.ChangePassword(context.Background(), user.ID.String(), uuid.NewString(), dto.ChangePasswordRequest{NewPassword: "second password"})
+	assert.ErrorIs(t, err, dto.ErrPasswordNotMatch)
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPersonalAccessToken = constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX + "secret"
+
+// personalAccessTokenJWTService only knows testPersonalAccessToken
+type personalAccessTokenJWTService struct {
+	service.JWTService
+	userId uuid.UUID
+	scopes string
+}
+
+func (s personalAccessTokenJWTService) ValidatePersonalAccessToken(token string) (entity.PersonalAccessToken, error) {
+	if token != testPersonalAccessToken {
+		return entity.PersonalAccessToken{}, dto.ErrInvalidPersonalAccessToken
+	}
+
+	return entity.PersonalAccessToken{Scopes: s.scopes, UserID: s.userId, User: entity.User{Role: constants.ENUM_ROLE_USER}}, nil
+}
+
+func personalAccessTokenRequest(jwtService service.JWTService, token string, scopes ...string) (int, string) {
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+
+	var userId string
+	server.GET("/", middleware.Authenticate(jwtService, scopes...), func(ctx *gin.Context) {
+		userId = ctx.GetString("user_id")
+		ctx.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, req)
+	return recorder.Code, userId
+}
+
+func Test_PersonalAccessTokenNeedsRouteScope(t *testing.T) {
+	jwtService := personalAccessTokenJWTService{userId: uuid.New(), scopes: "read post:write"}
+
+	code, userId := personalAccessTokenRequest(jwtService, testPersonalAccessToken, constants.ENUM_SCOPE_POST_WRITE)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, jwtService.userId.String(), userId)
+
+	code, _ = personalAccessTokenRequest(jwtService, testPersonalAccessToken, constants.ENUM_SCOPE_LIKES_WRITE)
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func Test_PersonalAccessTokenRejectedWithoutScopes(t *testing.T) {
+	jwtService := personalAccessTokenJWTService{userId: uuid.New(), scopes: "read post:write likes:write"}
+
+	code, _ := personalAccessTokenRequest(jwtService, testPersonalAccessToken)
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func Test_PersonalAccessTokenInvalid(t *testing.T) {
+	jwtService := personalAccessTokenJWTService{userId: uuid.New(), scopes: "read"}
+
+	code, _ := personalAccessTokenRequest(jwtService, constants.ENUM_PERSONAL_ACCESS_TOKEN_PREFIX+"other", constants.ENUM_SCOPE_READ)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
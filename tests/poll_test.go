@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// verifiedUserRepository returns a verified user for any id
+type verifiedUserRepository struct {
+	repository.UserRepository
+}
+
+func (r verifiedUserRepository) GetUserById(ctx context.Context, tx *gorm.DB, userId string) (entity.User, error) {
+	now := time.Now()
+	return entity.User{ID: uuid.MustParse(userId), EmailVerifiedAt: &now}, nil
+}
+
+type unblockedRepository struct {
+	repository.BlockRepository
+}
+
+func (r unblockedRepository) CheckBlockedBetween(ctx context.Context, tx *gorm.DB, userId string, otherId string) (bool, error) {
+	return false, nil
+}
+
+// votePollRepository keeps the votes of a single poll in memory
+type votePollRepository struct {
+	repository.PollRepository
+	poll  *entity.Poll
+	votes map[string]string
+}
+
+func (r votePollRepository) Vote(ctx context.Context, tx *gorm.DB, pollId string, optionId string, userId string) (bool, error) {
+	if _, ok := r.votes[userId]; ok {
+		return false, nil
+	}
+	r.votes[userId] = optionId
+
+	r.poll.TotalVotes++
+	for i := range r.poll.Options {
+		if r.poll.Options[i].ID.String() == optionId {
+			r.poll.Options[i].TotalVotes++
+		}
+	}
+
+	return true, nil
+}
+
+func (r votePollRepository) GetPollByPostId(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Poll, error) {
+	return *r.poll, nil
+}
+
+func Test_CreatePostPollValidation(t *testing.T) {
+	postService := newPostService(postServiceDeps{userRepo: verifiedUserRepository{}})
+	userId := uuid.New().String()
+
+	for _, c := range []struct {
+		req dto.PostCreateRequest
+		err error
+	}{
+		{dto.PostCreateRequest{PollOptions: []string{"yes"}, PollDurationMinutes: 60}, dto.ErrPollOptions},
+		{dto.PostCreateRequest{PollOptions: []string{"a", "b", "c", "d", "e"}, PollDurationMinutes: 60}, dto.ErrPollOptions},
+		{dto.PostCreateRequest{PollOptions: []string{"yes", "no"}, PollDurationMinutes: 1}, dto.ErrPollDuration},
+		{dto.PostCreateRequest{PollOptions: []string{"yes", "no"}, PollDurationMinutes: 8 * 24 * 60}, dto.ErrPollDuration},
+		{dto.PostCreateRequest{PollOptions: []string{"yes", " "}, PollDurationMinutes: 60}, dto.ErrPollOptionText},
+		{dto.PostCreateRequest{PollOptions: []string{"yes", strings.Repeat("n", 26)}, PollDurationMinutes: 60}, dto.ErrPollOptionText},
+		{dto.PostCreateRequest{PollOptions: []string{"yes", "no"}, PollDurationMinutes: 60, Media: []*multipart.FileHeader{{}}}, dto.ErrPollWithMedia},
+	} {
+		_, err := postService.CreatePost(context.Background(), userId, c.req)
+		assert.ErrorIs(t, err, c.err)
+	}
+}
+
+func Test_VotePoll(t *testing.T) {
+	yes, no := uuid.New(), uuid.New()
+	poll := &entity.Poll{
+		ID:       uuid.New(),
+		ClosesAt: time.Now().Add(time.Hour),
+		Options: []entity.PollOption{
+			{ID: yes, Position: 0, Text: "yes"},
+			{ID: no, Position: 1, Text: "no"},
+		},
+	}
+	post := entity.Post{ID: 1, UserID: uuid.New(), Poll: poll}
+
+	pollRepo := votePollRepository{poll: poll, votes: map[string]string{}}
+	postService := newPostService(postServiceDeps{postRepo: editPostRepository{post: post}, blockRepo: unblockedRepository{}, pollRepo: pollRepo})
+	voter := uuid.New().String()
+
+	_, err := postService.VotePoll(context.Background(), voter, post.ID, dto.PollVoteRequest{OptionID: uuid.New().String()})
+	assert.ErrorIs(t, err, dto.ErrPollOptionInvalid)
+
+	result, err := postService.VotePoll(context.Background(), voter, post.ID, dto.PollVoteRequest{OptionID: yes.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, yes.String(), *result.VotedOptionID)
+	assert.Equal(t, uint64(1), *result.TotalVotes)
+	assert.Equal(t, uint64(1), *result.Options[0].Votes)
+	assert.Equal(t, uint64(0), *result.Options[1].Votes)
+
+	_, err = postService.VotePoll(context.Background(), voter, post.ID, dto.PollVoteRequest{OptionID: no.String()})
+	assert.ErrorIs(t, err, dto.ErrAlreadyVoted)
+
+	poll.ClosesAt = time.Now().Add(-time.Minute)
+	_, err = postService.VotePoll(context.Background(), uuid.New().String(), post.ID, dto.PollVoteRequest{OptionID: no.String()})
+	assert.ErrorIs(t, err, dto.ErrPollClosed)
+}
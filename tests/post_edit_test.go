@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// editPostRepository only knows the one post it was given
+type editPostRepository struct {
+	repository.PostRepository
+	post entity.Post
+}
+
+func (r editPostRepository) GetPostById(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Post, error) {
+	return r.post, nil
+}
+
+// editPostRevisionRepository acts as if another edit always got there first
+type editPostRevisionRepository struct {
+	repository.PostRevisionRepository
+}
+
+func (r editPostRevisionRepository) EditPost(ctx context.Context, tx *gorm.DB, post entity.Post, text string, editedAt time.Time) (bool, error) {
+	return false, nil
+}
+
+func editPost(post entity.Post, text string) error {
+	postService := newPostService(postServiceDeps{
+		postRepo:         editPostRepository{post: post},
+		postRevisionRepo: editPostRevisionRepository{},
+		editConfig: config.PostEditConfig{
+			Window:   time.Hour,
+			MaxEdits: 2,
+		},
+	})
+
+	_, err := postService.UpdatePostById(context.Background(), post.UserID.String(), post.ID, dto.PostUpdateRequest{Text: text})
+	return err
+}
+
+func Test_PostEditLimits(t *testing.T) {
+	post := entity.Post{ID: 1, Text: "hello", UserID: uuid.New()}
+
+	post.CreatedAt = time.Now().Add(-2 * time.Hour)
+	assert.ErrorIs(t, editPost(post, "hello world"), dto.ErrEditWindowClosed)
+
+	post.CreatedAt = time.Now()
+	post.EditCount = 2
+	assert.ErrorIs(t, editPost(post, "hello world"), dto.ErrEditLimitReached)
+
+	post.EditCount = 1
+	assert.ErrorIs(t, editPost(post, "hello world"), dto.ErrEditConflict)
+}
@@ -0,0 +1,22 @@
+package tests
+
+import (
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+)
+
+// postServiceDeps names the dependencies of a post service under test, the
+// ones left out are nil and must not be reached.
+type postServiceDeps struct {
+	userRepo         repository.UserRepository
+	postRepo         repository.PostRepository
+	postRevisionRepo repository.PostRevisionRepository
+	blockRepo        repository.BlockRepository
+	pollRepo         repository.PollRepository
+	editConfig       config.PostEditConfig
+}
+
+func newPostService(deps postServiceDeps) service.PostService {
+	return service.NewPostService(deps.userRepo, deps.postRepo, deps.postRevisionRepo, nil, nil, nil, nil, deps.blockRepo, deps.pollRepo, nil, nil, deps.editConfig, config.MediaConfig{}, nil)
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/middleware"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TakeTokenRefills(t *testing.T) {
+	rate := config.Rate{Limit: 2, Period: time.Minute}
+	now := time.Now()
+
+	bucket, result := utils.TakeToken(nil, rate, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+
+	bucket, result = utils.TakeToken(&bucket, rate, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	bucket, result = utils.TakeToken(&bucket, rate, now)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+	_, result = utils.TakeToken(&bucket, rate, now.Add(30*time.Second))
+	assert.True(t, result.Allowed)
+}
+
+func Test_MemoryRateLimitStoreKeys(t *testing.T) {
+	store := utils.NewMemoryRateLimitStore()
+	rate := config.Rate{Limit: 1, Period: time.Hour}
+
+	result, err := store.Take(context.Background(), "a", rate)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take(context.Background(), "a", rate)
+	assert.False(t, result.Allowed)
+
+	result, _ = store.Take(context.Background(), "b", rate)
+	assert.True(t, result.Allowed)
+}
+
+func Test_RateLimitMiddlewareHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	server.GET("/", middleware.RateLimitByIP(utils.NewMemoryRateLimitStore(), "test", config.Rate{Limit: 1, Period: time.Minute}), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	res := httptest.NewRecorder()
+	server.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "1", res.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", res.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", res.Header().Get("RateLimit-Reset"))
+
+	res = httptest.NewRecorder()
+	server.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, res.Code)
+	assert.Equal(t, "60", res.Header().Get("Retry-After"))
+}
+
+func Test_ParseRate(t *testing.T) {
+	rate, ok := config.ParseRate("10/1m")
+	assert.True(t, ok)
+	assert.Equal(t, config.Rate{Limit: 10, Period: time.Minute}, rate)
+
+	_, ok = config.ParseRate("10")
+	assert.False(t, ok)
+}
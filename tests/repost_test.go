@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// repostRepository serves a repost and its original, and records which post
+// a like was counted on
+type repostRepository struct {
+	repository.PostRepository
+	posts map[uint64]entity.Post
+	liked *uint64
+}
+
+func (r repostRepository) GetPostById(ctx context.Context, tx *gorm.DB, postId uint64) (entity.Post, error) {
+	return r.posts[postId], nil
+}
+
+func (r repostRepository) UpdateLikesCount(ctx context.Context, tx *gorm.DB, postId uint64, count int) error {
+	*r.liked = postId
+	return nil
+}
+
+type likesRepository struct {
+	repository.LikesRepository
+	liked *uint64
+}
+
+func (r likesRepository) LikePostById(ctx context.Context, tx *gorm.DB, postId uint64, userId string) error {
+	*r.liked = postId
+	return nil
+}
+
+type silentNotificationService struct {
+	service.NotificationService
+}
+
+func (s silentNotificationService) Notify(ctx context.Context, notifyType string, actorId string, recipientId string, postId *uint64) error {
+	return nil
+}
+
+func Test_LikeThroughRepost(t *testing.T) {
+	original := entity.Post{ID: 1, Text: "hello", UserID: uuid.New()}
+	repost := entity.Post{ID: 2, UserID: uuid.New(), RepostOfID: &original.ID, RepostOf: &original}
+
+	var liked, counted uint64
+	postRepo := repostRepository{posts: map[uint64]entity.Post{1: original, 2: repost}, liked: &counted}
+	likesService := service.NewLikesService(likesRepository{liked: &liked}, postRepo, unblockedRepository{}, silentNotificationService{}, nil)
+
+	assert.NoError(t, likesService.LikePostById(context.Background(), repost.ID, uuid.NewString()))
+	assert.Equal(t, original.ID, liked)
+	assert.Equal(t, original.ID, counted)
+
+	original.DeletedAt = gorm.DeletedAt{Valid: true}
+	postRepo.posts[2] = entity.Post{ID: 2, UserID: repost.UserID, RepostOfID: &original.ID, RepostOf: &original}
+	assert.Error(t, likesService.LikePostById(context.Background(), repost.ID, uuid.NewString()))
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// memorySessionRepository keeps sessions and refresh tokens in maps
+type memorySessionRepository struct {
+	repository.SessionRepository
+	sessions map[uuid.UUID]*entity.Session
+	tokens   map[string]*entity.RefreshToken
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{
+		sessions: map[uuid.UUID]*entity.Session{},
+		tokens:   map[string]*entity.RefreshToken{},
+	}
+}
+
+func (r *memorySessionRepository) CreateSession(ctx context.Context, tx *gorm.DB, session entity.Session) (entity.Session, error) {
+	session.ID = uuid.New()
+	r.sessions[session.ID] = &session
+	return session, nil
+}
+
+func (r *memorySessionRepository) TouchSession(ctx context.Context, tx *gorm.DB, sessionId string, meta dto.SessionMetadata, expiresAt time.Time) error {
+	r.sessions[uuid.MustParse(sessionId)].ExpiresAt = expiresAt
+	return nil
+}
+
+func (r *memorySessionRepository) RevokeSession(ctx context.Context, tx *gorm.DB, sessionId string) error {
+	now := time.Now()
+	r.sessions[uuid.MustParse(sessionId)].RevokedAt = &now
+	return nil
+}
+
+func (r *memorySessionRepository) CreateRefreshToken(ctx context.Context, tx *gorm.DB, token entity.RefreshToken) error {
+	token.ID = uint64(len(r.tokens) + 1)
+	r.tokens[token.TokenHash] = &token
+	return nil
+}
+
+func (r *memorySessionRepository) GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (entity.RefreshToken, error) {
+	token, ok := r.tokens[tokenHash]
+	if !ok {
+		return entity.RefreshToken{}, gorm.ErrRecordNotFound
+	}
+
+	result := *token
+	result.Session = *r.sessions[token.SessionID]
+	return result, nil
+}
+
+func (r *memorySessionRepository) MarkRefreshTokenUsed(ctx context.Context, tx *gorm.DB, tokenId uint64) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == tokenId {
+			if token.UsedAt != nil {
+				return false, nil
+			}
+
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+
+	return false, gorm.ErrRecordNotFound
+}
+
+type accessTokenJWTService struct {
+	service.JWTService
+}
+
+func (s accessTokenJWTService) GenerateToken(userId string, sessionId string, role string) (string, error) {
+	return "access-" + sessionId, nil
+}
+
+func Test_RefreshRotatesToken(t *testing.T) {
+	sessionRepo := newMemorySessionRepository()
+	sessionService := service.NewSessionService(verifiedUserRepository{}, sessionRepo, config.SessionConfig{AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}, accessTokenJWTService{})
+
+	login, err := sessionService.CreateSession(context.Background(), uuid.NewString(), dto.SessionMetadata{})
+	assert.NoError(t, err)
+
+	refreshed, err := sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: login.RefreshToken}, dto.SessionMetadata{})
+	assert.NoError(t, err)
+	assert.Equal(t, login.SessionID, refreshed.SessionID)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+	refreshed, err = sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken}, dto.SessionMetadata{})
+	assert.NoError(t, err)
+	assert.Equal(t, login.SessionID, refreshed.SessionID)
+
+	_, err = sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: "unknown"}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrInvalidRefreshToken)
+}
+
+func Test_RefreshReuseRevokesSession(t *testing.T) {
+	sessionRepo := newMemorySessionRepository()
+	sessionService := service.NewSessionService(verifiedUserRepository{}, sessionRepo, config.SessionConfig{AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}, accessTokenJWTService{})
+
+	login, err := sessionService.CreateSession(context.Background(), uuid.NewString(), dto.SessionMetadata{})
+	assert.NoError(t, err)
+
+	refreshed, err := sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: login.RefreshToken}, dto.SessionMetadata{})
+	assert.NoError(t, err)
+
+	// the rotated token showing up again means it leaked
+	_, err = sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: login.RefreshToken}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrRefreshTokenReused)
+	assert.NotNil(t, sessionRepo.sessions[uuid.MustParse(login.SessionID)].RevokedAt)
+
+	// which takes the token handed out in its place down with the session
+	_, err = sessionService.Refresh(context.Background(), dto.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrSessionRevoked)
+}
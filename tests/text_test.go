@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExtractMentions(t *testing.T) {
+	mentions := utils.ExtractMentions("hi @alice and @bob_1, mail me at me@example.com @@no @")
+
+	assert.Equal(t, []utils.TextEntity{
+		{Value: "alice", Start: 3, End: 9},
+		{Value: "bob_1", Start: 14, End: 20},
+	}, mentions)
+}
+
+func Test_ExtractMentionsRuneOffsets(t *testing.T) {
+	mentions := utils.ExtractMentions("héllo 👋 @alice")
+
+	assert.Equal(t, []utils.TextEntity{
+		{Value: "alice", Start: 8, End: 14},
+	}, mentions)
+}
+
+func Test_ExtractHashtags(t *testing.T) {
+	hashtags := utils.ExtractHashtags("#GoLang rocks #1 #gin_gonic, not a#tag")
+
+	assert.Equal(t, []utils.TextEntity{
+		{Value: "GoLang", Start: 0, End: 7},
+		{Value: "gin_gonic", Start: 17, End: 27},
+	}, hashtags)
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateToken(t *testing.T) {
+	first, err := helpers.GenerateToken()
+	assert.NoError(t, err)
+
+	second, err := helpers.GenerateToken()
+	assert.NoError(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+}
+
+func Test_HashToken(t *testing.T) {
+	assert.Equal(t, helpers.HashToken("token"), helpers.HashToken("token"))
+	assert.NotEqual(t, helpers.HashToken("token"), helpers.HashToken("token2"))
+	assert.Len(t, helpers.HashToken("token"), 64)
+}
+
+func Test_SignedToken(t *testing.T) {
+	token := helpers.SignToken("secret", "user|user@example.com", time.Now().Add(time.Hour))
+
+	payload, err := helpers.VerifySignedToken("secret", token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user|user@example.com", payload)
+
+	_, err = helpers.VerifySignedToken("other secret", token)
+	assert.ErrorIs(t, err, helpers.ErrSignedTokenInvalid)
+
+	_, err = helpers.VerifySignedToken("secret", token+"x")
+	assert.ErrorIs(t, err, helpers.ErrSignedTokenInvalid)
+}
+
+func Test_SignedTokenExpired(t *testing.T) {
+	token := helpers.SignToken("secret", "user", time.Now().Add(-time.Minute))
+
+	_, err := helpers.VerifySignedToken("secret", token)
+	assert.ErrorIs(t, err, helpers.ErrSignedTokenExpired)
+}
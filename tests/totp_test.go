@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// secret "12345678901234567890" from the RFC 6238 test vectors
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func Test_TOTPCode(t *testing.T) {
+	code, err := helpers.TOTPCode(rfcTOTPSecret, helpers.TOTPStep(time.Unix(59, 0)))
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = helpers.TOTPCode(rfcTOTPSecret, helpers.TOTPStep(time.Unix(1111111109, 0)))
+	assert.NoError(t, err)
+	assert.Equal(t, "081804", code)
+}
+
+func Test_ValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	step, valid := helpers.ValidateTOTP(rfcTOTPSecret, "081804", now.Add(30*time.Second))
+	assert.True(t, valid)
+	assert.Equal(t, helpers.TOTPStep(now), step)
+
+	_, valid = helpers.ValidateTOTP(rfcTOTPSecret, "081804", now.Add(2*time.Minute))
+	assert.False(t, valid)
+
+	_, valid = helpers.ValidateTOTP(rfcTOTPSecret, "81804", now)
+	assert.False(t, valid)
+}
+
+func Test_TOTPProvisioningURI(t *testing.T) {
+	uri := helpers.TOTPProvisioningURI("Twitter Clone", "johndoe", rfcTOTPSecret)
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Twitter%20Clone:johndoe?"))
+	assert.Contains(t, uri, "secret="+rfcTOTPSecret)
+	assert.Contains(t, uri, "issuer=Twitter+Clone")
+}
+
+func Test_TOTPSecretEncryption(t *testing.T) {
+	encrypted, err := utils.AESEncryptWithKey("encryption key", rfcTOTPSecret)
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, rfcTOTPSecret)
+
+	secret, err := utils.AESDecryptWithKey("encryption key", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, rfcTOTPSecret, secret)
+
+	_, err = utils.AESDecryptWithKey("another key", encrypted)
+	assert.ErrorIs(t, err, utils.ErrDecrypt)
+
+	_, err = utils.AESDecryptWithKey("encryption key", "00")
+	assert.ErrorIs(t, err, utils.ErrDecrypt)
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/dto"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/entity"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/helpers"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/repository"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// twoFactorUserRepository serves one user with two factor authentication
+// and counts failed logins
+type twoFactorUserRepository struct {
+	repository.UserRepository
+	user   *entity.User
+	failed *int
+}
+
+func (r twoFactorUserRepository) GetUserById(ctx context.Context, tx *gorm.DB, userId string) (entity.User, error) {
+	return *r.user, nil
+}
+
+func (r twoFactorUserRepository) RecordFailedLogin(ctx context.Context, tx *gorm.DB, userId string) (int, error) {
+	*r.failed++
+	return *r.failed, nil
+}
+
+func (r twoFactorUserRepository) UpdateLockedUntil(ctx context.Context, tx *gorm.DB, userId string, lockedUntil time.Time) error {
+	r.user.LockedUntil = &lockedUntil
+	return nil
+}
+
+// challengeRepository remembers which challenges were answered
+type challengeRepository struct {
+	repository.TwoFactorChallengeRepository
+	used map[string]bool
+}
+
+func (r challengeRepository) UseChallenge(ctx context.Context, tx *gorm.DB, challengeId string, userId string, now time.Time) (bool, error) {
+	if r.used[challengeId] {
+		return false, nil
+	}
+	r.used[challengeId] = true
+	return true, nil
+}
+
+func Test_TwoFactorChallenge(t *testing.T) {
+	now := time.Now()
+	user := &entity.User{ID: uuid.New(), TwoFactorEnabledAt: &now}
+	failed := 0
+
+	twoFactorConfig := config.TwoFactorConfig{Secret: "two factor secret"}
+	lockoutConfig := config.LoginLockoutConfig{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour}
+	twoFactorService := service.NewTwoFactorService(
+		twoFactorUserRepository{user: user, failed: &failed}, nil,
+		challengeRepository{used: map[string]bool{}}, nil,
+		twoFactorConfig, lockoutConfig,
+	)
+
+	challenge := func(secret string) string {
+		return helpers.SignToken(secret, "2fa:"+uuid.NewString()+"|"+user.ID.String(), now.Add(time.Minute))
+	}
+
+	_, err := twoFactorService.Login(context.Background(), dto.TwoFactorLoginRequest{ChallengeToken: challenge("another secret"), Code: "123456"}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrInvalidChallenge)
+
+	token := challenge(twoFactorConfig.Secret)
+	_, err = twoFactorService.Login(context.Background(), dto.TwoFactorLoginRequest{ChallengeToken: token, Code: "123456"}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrTwoFactorNotEnabled)
+	assert.Equal(t, 1, failed)
+
+	_, err = twoFactorService.Login(context.Background(), dto.TwoFactorLoginRequest{ChallengeToken: token, Code: "123456"}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrInvalidChallenge)
+	assert.Equal(t, 1, failed)
+
+	_, err = twoFactorService.Login(context.Background(), dto.TwoFactorLoginRequest{ChallengeToken: challenge(twoFactorConfig.Secret), Code: "123456"}, dto.SessionMetadata{})
+	assert.Error(t, err)
+	assert.Equal(t, 2, failed)
+	assert.NotNil(t, user.LockedUntil)
+
+	_, err = twoFactorService.Login(context.Background(), dto.TwoFactorLoginRequest{ChallengeToken: challenge(twoFactorConfig.Secret), Code: "123456"}, dto.SessionMetadata{})
+	assert.ErrorIs(t, err, dto.ErrAccountLocked)
+}
@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+)
+
+//go:embed email-template/*.html
+var emailTemplates embed.FS
+
+// ParseEmailTemplate renders one of the html files in email-template
+func ParseEmailTemplate(name string, data any) (string, error) {
+	tmpl, err := template.ParseFS(emailTemplates, "email-template/"+name+".html")
+	if err != nil {
+		return "", err
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", err
+	}
+
+	return body.String(), nil
+}
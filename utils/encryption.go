@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+var ErrDecrypt = errors.New("failed to decrypt")
+
+// AESEncryptWithKey seals plaintext with AES-256-GCM under a key derived
+// from the configured secret, the random nonce is prepended to the hex
+// encoded result.
+func AESEncryptWithKey(secret string, plaintext string) (string, error) {
+	aesGCM, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(aesGCM.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+// AESDecryptWithKey opens what AESEncryptWithKey sealed with the same secret
+func AESDecryptWithKey(secret string, encrypted string) (string, error) {
+	aesGCM, err := newGCM(secret)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := hex.DecodeString(encrypted)
+	if err != nil || len(data) < aesGCM.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, ciphertext := data[:aesGCM.NonceSize()], data[aesGCM.NonceSize():]
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+
+	return string(plaintext), nil
+}
+
+func newGCM(secret string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(secret))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
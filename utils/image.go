@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"path"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Sizes are written as lossy WebP by the encoder in webp.go, neither the
+// standard library nor x/image can encode it. The format is only set here,
+// keys of stored pictures don't depend on it.
+const (
+	ImageVariantExtension = "webp"
+	ImageVariantMimeType  = "image/webp"
+	imageVariantQuality   = 85
+)
+
+var (
+	ErrInvalidImage    = errors.New("not a JPEG, PNG, GIF or WebP image")
+	ErrImageDimensions = errors.New("image dimensions out of range")
+)
+
+// AvatarVariants and BannerVariants are the sizes generated from uploaded
+// profile pictures and banners, named by their width.
+var (
+	AvatarVariants = []ImageVariant{
+		{Width: 48, Height: 48},
+		{Width: 128, Height: 128},
+		{Width: 400, Height: 400},
+	}
+	BannerVariants = []ImageVariant{
+		{Width: 600, Height: 200},
+		{Width: 1500, Height: 500},
+	}
+)
+
+type (
+	ImageVariant struct {
+		Width  int
+		Height int
+	}
+
+	ProcessedImage struct {
+		Variant ImageVariant
+		Data    []byte
+	}
+)
+
+func (v ImageVariant) Name() string {
+	return strconv.Itoa(v.Width)
+}
+
+// ImageVariantKey is where a size of the picture stored under key lives.
+// Keys with an extension are pictures uploaded before sizes were generated,
+// the single file then stands in for every size.
+func ImageVariantKey(key string, variant ImageVariant) string {
+	if path.Ext(key) != "" {
+		return key
+	}
+
+	return key + "/" + variant.Name() + "." + ImageVariantExtension
+}
+
+// ImageVariantKeys lists every file stored for the picture under key
+func ImageVariantKeys(key string, variants []ImageVariant) []string {
+	if path.Ext(key) != "" {
+		return []string{key}
+	}
+
+	keys := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		keys = append(keys, ImageVariantKey(key, variant))
+	}
+
+	return keys
+}
+
+// ProcessImage decodes an uploaded picture and renders it center-cropped to
+// every variant. The dimensions are checked before decoding so a small file
+// can't expand into a huge bitmap. Only pixels are re-encoded, EXIF data
+// like the GPS position is dropped after its orientation has been applied.
+func ProcessImage(content io.Reader, variants []ImageVariant, maxPixels int) ([]ProcessedImage, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, ErrImageDimensions
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	src = orientImage(src, jpegOrientation(data))
+
+	images := make([]ProcessedImage, 0, len(variants))
+	for _, variant := range variants {
+		var buf bytes.Buffer
+		if err := encodeWebP(&buf, cropImage(src, variant), imageVariantQuality); err != nil {
+			return nil, err
+		}
+
+		images = append(images, ProcessedImage{
+			Variant: variant,
+			Data:    buf.Bytes(),
+		})
+	}
+
+	return images, nil
+}
+
+// cropImage cuts the largest centered area with the aspect ratio of the
+// variant and scales it to size. Transparent parts end up white as the
+// variants are written without an alpha channel.
+func cropImage(src image.Image, variant ImageVariant) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	crop := bounds
+	if width*variant.Height > height*variant.Width {
+		cropWidth := height * variant.Width / variant.Height
+		crop.Min.X += (width - cropWidth) / 2
+		crop.Max.X = crop.Min.X + cropWidth
+	} else {
+		cropHeight := width * variant.Height / variant.Width
+		crop.Min.Y += (height - cropHeight) / 2
+		crop.Max.Y = crop.Min.Y + cropHeight
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, variant.Width, variant.Height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+
+	return dst
+}
+
+// orientImage turns the stored pixels the way the EXIF orientation says
+// they are meant to be shown, cameras store photos as taken.
+func orientImage(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	pixels := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(pixels, pixels.Bounds(), src, bounds.Min, draw.Src)
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], pixels.Pix[pixels.PixOffset(sx, sy):pixels.PixOffset(sx, sy)+4])
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation reads the orientation tag from the EXIF segment of a JPEG,
+// 1 (as stored) for other formats or when there is none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// fill byte before a marker
+			i++
+			continue
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			i += 2
+			continue
+		case marker == 0xDA || marker == 0xD9:
+			// image data starts, metadata comes before it
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < entries; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(tiff[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			break
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+type (
+	Mailer interface {
+		Send(toEmail string, subject string, body string) error
+	}
+
+	MailMessage struct {
+		To      string
+		Subject string
+		Body    string
+	}
+
+	smtpMailer struct{}
+
+	fileMailer struct {
+		dir string
+	}
+
+	MemoryMailer struct {
+		mu       sync.Mutex
+		messages []MailMessage
+	}
+)
+
+func NewMailer(mailConfig config.MailConfig) Mailer {
+	switch mailConfig.Driver {
+	case constants.ENUM_MAIL_DRIVER_FILE:
+		return NewFileMailer(mailConfig.FileDir)
+	case constants.ENUM_MAIL_DRIVER_MEMORY:
+		return NewMemoryMailer()
+	default:
+		return &smtpMailer{}
+	}
+}
+
+func (m *smtpMailer) Send(toEmail string, subject string, body string) error {
+	return SendMail(toEmail, subject, body)
+}
+
+// NewFileMailer writes every email into dir instead of sending it, handy
+// to click through links while developing without an SMTP server.
+func NewFileMailer(dir string) Mailer {
+	return &fileMailer{
+		dir: dir,
+	}
+}
+
+func (m *fileMailer) Send(toEmail string, subject string, body string) error {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.ReplaceAll(toEmail, "@", "_at_"))
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s", toEmail, subject, body)
+
+	return os.WriteFile(filepath.Join(m.dir, name), []byte(content), 0644)
+}
+
+// NewMemoryMailer keeps sent emails in memory so tests can read them back
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(toEmail string, subject string, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, MailMessage{
+		To:      toEmail,
+		Subject: subject,
+		Body:    body,
+	})
+	return nil
+}
+
+func (m *MemoryMailer) Messages() []MailMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]MailMessage(nil), m.messages...)
+}
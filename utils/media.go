@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+var (
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+	ErrInvalidMedia     = errors.New("media file is damaged or has no dimensions")
+)
+
+// MediaInfo describes an uploaded file by its content, the name and content
+// type sent by the client are never trusted.
+type MediaInfo struct {
+	Type      string
+	MimeType  string
+	Extension string
+	Width     int
+	Height    int
+}
+
+var mediaTypes = map[string]MediaInfo{
+	"image/jpeg": {Type: constants.ENUM_MEDIA_IMAGE, Extension: "jpg"},
+	"image/png":  {Type: constants.ENUM_MEDIA_IMAGE, Extension: "png"},
+	"image/webp": {Type: constants.ENUM_MEDIA_IMAGE, Extension: "webp"},
+	"image/gif":  {Type: constants.ENUM_MEDIA_GIF, Extension: "gif"},
+	"video/mp4":  {Type: constants.ENUM_MEDIA_VIDEO, Extension: "mp4"},
+}
+
+// DetectMedia sniffs the type of the file from its first bytes and reads
+// its dimensions from the headers, without decoding the whole file.
+func DetectMedia(file io.ReaderAt, size int64) (MediaInfo, error) {
+	head := make([]byte, 512)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return MediaInfo{}, err
+	}
+	head = head[:n]
+
+	mimeType := http.DetectContentType(head)
+	info, ok := mediaTypes[mimeType]
+	if !ok {
+		return MediaInfo{}, ErrUnsupportedMedia
+	}
+	info.MimeType = mimeType
+
+	switch mimeType {
+	case "image/webp":
+		info.Width, info.Height = webpDimensions(head)
+	case "video/mp4":
+		info.Width, info.Height = mp4Dimensions(file, 0, size, 0)
+	default:
+		config, _, err := image.DecodeConfig(io.NewSectionReader(file, 0, size))
+		if err != nil {
+			return MediaInfo{}, ErrInvalidMedia
+		}
+		info.Width, info.Height = config.Width, config.Height
+	}
+
+	if info.Width <= 0 || info.Height <= 0 {
+		return MediaInfo{}, ErrInvalidMedia
+	}
+
+	return info, nil
+}
+
+// webpDimensions reads the canvas size of lossy (VP8), lossless (VP8L) and
+// extended (VP8X) WebP files.
+func webpDimensions(head []byte) (int, int) {
+	if len(head) < 30 {
+		return 0, 0
+	}
+
+	switch string(head[12:16]) {
+	case "VP8 ":
+		if head[23] != 0x9d || head[24] != 0x01 || head[25] != 0x2a {
+			return 0, 0
+		}
+		return int(binary.LittleEndian.Uint16(head[26:28]) & 0x3fff), int(binary.LittleEndian.Uint16(head[28:30]) & 0x3fff)
+	case "VP8L":
+		if head[20] != 0x2f {
+			return 0, 0
+		}
+		bits := binary.LittleEndian.Uint32(head[21:25])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1
+	case "VP8X":
+		width := uint32(head[24]) | uint32(head[25])<<8 | uint32(head[26])<<16
+		height := uint32(head[27]) | uint32(head[28])<<8 | uint32(head[29])<<16
+		return int(width) + 1, int(height) + 1
+	}
+
+	return 0, 0
+}
+
+// mp4Dimensions walks the boxes between start and end looking for the first
+// track header with a size, going into moov and trak boxes. The moov box may
+// come after the media data, so boxes are skipped rather than read.
+func mp4Dimensions(file io.ReaderAt, start int64, end int64, depth int) (int, int) {
+	if depth > 2 {
+		return 0, 0
+	}
+
+	header := make([]byte, 16)
+	for offset := start; offset+8 <= end; {
+		if _, err := file.ReadAt(header[:8], offset); err != nil {
+			return 0, 0
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+
+		switch boxSize {
+		case 0:
+			boxSize = end - offset
+		case 1:
+			if _, err := file.ReadAt(header[8:16], offset+8); err != nil {
+				return 0, 0
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		if boxSize < headerSize || offset+boxSize > end {
+			return 0, 0
+		}
+
+		switch boxType {
+		case "moov", "trak":
+			if width, height := mp4Dimensions(file, offset+headerSize, offset+boxSize, depth+1); width > 0 && height > 0 {
+				return width, height
+			}
+		case "tkhd":
+			if width, height := tkhdDimensions(file, offset+headerSize, boxSize-headerSize); width > 0 && height > 0 {
+				return width, height
+			}
+		}
+
+		offset += boxSize
+	}
+
+	return 0, 0
+}
+
+// tkhdDimensions reads the 16.16 fixed point width and height that end a
+// track header, audio tracks have them set to zero.
+func tkhdDimensions(file io.ReaderAt, offset int64, size int64) (int, int) {
+	if size < 84 || size > 256 {
+		return 0, 0
+	}
+
+	box := make([]byte, size)
+	if _, err := file.ReadAt(box, offset); err != nil {
+		return 0, 0
+	}
+
+	// version 1 headers use 64 bit times and duration
+	dimensions := 76
+	if box[0] == 1 {
+		dimensions = 88
+	}
+
+	if len(box) < dimensions+8 {
+		return 0, 0
+	}
+
+	return int(binary.BigEndian.Uint32(box[dimensions:]) >> 16), int(binary.BigEndian.Uint32(box[dimensions+4:]) >> 16)
+}
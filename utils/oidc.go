@@ -0,0 +1,337 @@
+package utils
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// how often the signing keys of a provider may be fetched again for an unknown kid
+const oidcKeysRefreshInterval = time.Minute
+
+var (
+	ErrOIDCDiscovery  = errors.New("failed to load provider configuration")
+	ErrOIDCExchange   = errors.New("failed to exchange authorization code")
+	ErrOIDCIDToken    = errors.New("invalid id token")
+	ErrOIDCUnknownKey = errors.New("id token signed with an unknown key")
+)
+
+type (
+	// OIDCClient talks to one OpenID Connect provider. The discovery document
+	// and signing keys are fetched on first use and cached.
+	OIDCClient struct {
+		config     config.OIDCProviderConfig
+		httpClient *http.Client
+
+		mu            sync.Mutex
+		discovery     *oidcDiscovery
+		keys          map[string]crypto.PublicKey
+		keysFetchedAt time.Time
+	}
+
+	// OIDCClaims are the verified ID token claims a login is based on
+	OIDCClaims struct {
+		Subject           string
+		Email             string
+		EmailVerified     bool
+		Name              string
+		PreferredUsername string
+	}
+
+	oidcDiscovery struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+
+	oidcTokenResponse struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	oidcJWK struct {
+		KeyType string `json:"kty"`
+		KeyID   string `json:"kid"`
+		Use     string `json:"use"`
+		N       string `json:"n"`
+		E       string `json:"e"`
+		Curve   string `json:"crv"`
+		X       string `json:"x"`
+		Y       string `json:"y"`
+	}
+)
+
+func NewOIDCClient(providerConfig config.OIDCProviderConfig) *OIDCClient {
+	return &OIDCClient{
+		config:     providerConfig,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// AuthorizationURL is where the user signs in with the provider, the PKCE
+// challenge ties the returned code to the verifier only the server knows.
+func (c *OIDCClient) AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.config.ClientID},
+		"redirect_uri":          {c.config.RedirectURL},
+		"scope":                 {strings.Join(c.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems the authorization code and returns the claims of the
+// verified ID token, which must carry the nonce of the login attempt.
+func (c *OIDCClient) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (OIDCClaims, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return OIDCClaims{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.config.RedirectURL},
+		"client_id":     {c.config.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return OIDCClaims{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if c.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(c.config.ClientSecret))
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return OIDCClaims{}, fmt.Errorf("%w: %v", ErrOIDCExchange, err)
+	}
+	defer res.Body.Close()
+
+	var token oidcTokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil {
+		return OIDCClaims{}, fmt.Errorf("%w: %v", ErrOIDCExchange, err)
+	}
+
+	if res.StatusCode != http.StatusOK || token.IDToken == "" {
+		return OIDCClaims{}, fmt.Errorf("%w: %s %s", ErrOIDCExchange, token.Error, token.ErrorDescription)
+	}
+
+	return c.verifyIDToken(ctx, discovery, token.IDToken, nonce)
+}
+
+func (c *OIDCClient) verifyIDToken(ctx context.Context, discovery oidcDiscovery, idToken string, nonce string) (OIDCClaims, error) {
+	parsed, err := jwt.Parse(idToken, func(t *jwt.Token) (any, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		default:
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return c.getKey(ctx, discovery, kid)
+	})
+	if err != nil {
+		return OIDCClaims{}, fmt.Errorf("%w: %v", ErrOIDCIDToken, err)
+	}
+
+	claims := parsed.Claims.(jwt.MapClaims)
+	if !claims.VerifyIssuer(discovery.Issuer, true) || !claims.VerifyAudience(c.config.ClientID, true) {
+		return OIDCClaims{}, ErrOIDCIDToken
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return OIDCClaims{}, ErrOIDCIDToken
+	}
+
+	result := OIDCClaims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+	result.PreferredUsername, _ = claims["preferred_username"].(string)
+
+	// some providers send email_verified as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	if result.Subject == "" {
+		return OIDCClaims{}, ErrOIDCIDToken
+	}
+
+	return result, nil
+}
+
+func (c *OIDCClient) getDiscovery(ctx context.Context) (oidcDiscovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return *c.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := c.getJSON(ctx, c.config.Issuer+"/.well-known/openid-configuration", &discovery); err != nil {
+		return oidcDiscovery{}, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+
+	if strings.TrimRight(discovery.Issuer, "/") != c.config.Issuer || discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return oidcDiscovery{}, ErrOIDCDiscovery
+	}
+
+	c.discovery = &discovery
+	return discovery, nil
+}
+
+// getKey refetches the key set when the kid is unknown, providers publish new
+// keys ahead of rotating to them.
+func (c *OIDCClient) getKey(ctx context.Context, discovery oidcDiscovery, kid string) (crypto.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.findKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(c.keysFetchedAt) < oidcKeysRefreshInterval {
+		return nil, ErrOIDCUnknownKey
+	}
+
+	var set struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+	if err := c.getJSON(ctx, discovery.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	c.keys = map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if key, err := jwk.publicKey(); err == nil {
+			c.keys[jwk.KeyID] = key
+		}
+	}
+	c.keysFetchedAt = time.Now()
+
+	if key, ok := c.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrOIDCUnknownKey
+}
+
+// findKey also accepts tokens without a kid when the provider has one key
+func (c *OIDCClient) findKey(kid string) (crypto.PublicKey, bool) {
+	if key, ok := c.keys[kid]; ok {
+		return key, true
+	}
+
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *OIDCClient) getJSON(ctx context.Context, endpoint string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, endpoint)
+	}
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+func (k oidcJWK) publicKey() (crypto.PublicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.KeyType {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Curve != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %s", k.Curve)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		x, err := decode(k.X)
+		if err != nil || k.Curve != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("unsupported okp key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.KeyType)
+	}
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+)
+
+// how often the memory store drops buckets that have refilled completely
+const rateLimitSweepInterval = time.Minute
+
+type (
+	// RateLimitStore takes one request from the token bucket stored under key.
+	// The memory store only limits a single instance, a shared store (e.g.
+	// Redis) can be plugged in to enforce limits across all of them.
+	RateLimitStore interface {
+		Take(ctx context.Context, key string, rate config.Rate) (RateLimitResult, error)
+	}
+
+	RateLimitResult struct {
+		Allowed   bool
+		Remaining int
+		// ResetAfter is how long until the bucket is full again
+		ResetAfter time.Duration
+		// RetryAfter is how long until the next request is let through, zero
+		// when this one was allowed
+		RetryAfter time.Duration
+	}
+
+	// TokenBucket is the state a store keeps per key
+	TokenBucket struct {
+		Tokens    float64
+		UpdatedAt time.Time
+	}
+
+	memoryRateLimitStore struct {
+		mu        sync.Mutex
+		buckets   map[string]*memoryBucket
+		lastSweep time.Time
+	}
+
+	memoryBucket struct {
+		TokenBucket
+		period time.Duration
+	}
+)
+
+// TakeToken refills the bucket for the time passed since it was last used
+// and takes a token out of it when one is left. Stores keep the returned
+// bucket and hand the result to the caller.
+func TakeToken(bucket *TokenBucket, rate config.Rate, now time.Time) (TokenBucket, RateLimitResult) {
+	capacity := float64(rate.Limit)
+	interval := float64(rate.Period) / capacity
+
+	next := TokenBucket{
+		Tokens:    capacity,
+		UpdatedAt: now,
+	}
+	if bucket != nil {
+		next.Tokens = math.Min(capacity, bucket.Tokens+float64(now.Sub(bucket.UpdatedAt))/interval)
+	}
+
+	var result RateLimitResult
+	if next.Tokens >= 1 {
+		next.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - next.Tokens) * interval)
+	}
+
+	result.Remaining = int(next.Tokens)
+	result.ResetAfter = time.Duration((capacity - next.Tokens) * interval)
+	return next, result
+}
+
+func NewMemoryRateLimitStore() RateLimitStore {
+	return &memoryRateLimitStore{
+		buckets:   map[string]*memoryBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryRateLimitStore) Take(ctx context.Context, key string, rate config.Rate) (RateLimitResult, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	var current *TokenBucket
+	if bucket, ok := s.buckets[key]; ok {
+		current = &bucket.TokenBucket
+	}
+
+	next, result := TakeToken(current, rate, now)
+	s.buckets[key] = &memoryBucket{
+		TokenBucket: next,
+		period:      rate.Period,
+	}
+
+	return result, nil
+}
+
+// sweep forgets buckets idle long enough to be full, a missing bucket starts
+// out full anyway.
+func (s *memoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.UpdatedAt) >= bucket.period {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+	"github.com/Lab-RPL-ITS/twitter-clone-api/constants"
+)
+
+var (
+	ErrInvalidStorageKey = errors.New("invalid storage key")
+	ErrInvalidSignedURL  = errors.New("invalid or expired link")
+)
+
+type (
+	// Storage keeps uploaded files under keys such as profile/<id>.png, the
+	// database only stores keys and links are made when a response is built.
+	Storage interface {
+		Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+		// Delete succeeds when there is nothing stored under the key
+		Delete(ctx context.Context, key string) error
+		// URL returns a signed link to the file that expires after a while
+		URL(key string) string
+	}
+
+	// LocalStorage keeps files on disk and serves them itself through
+	// links signed with its secret.
+	LocalStorage struct {
+		dir     string
+		baseURL string
+		secret  []byte
+		ttl     time.Duration
+	}
+)
+
+func NewStorage(storageConfig config.StorageConfig) Storage {
+	if storageConfig.Driver == constants.ENUM_STORAGE_DRIVER_S3 {
+		return NewS3Storage(storageConfig.S3, storageConfig.URLTTL)
+	}
+
+	return NewLocalStorage(storageConfig.LocalDir, storageConfig.LocalURL, storageConfig.Secret, storageConfig.URLTTL)
+}
+
+// PutFile stores an uploaded form file under key
+func PutFile(ctx context.Context, storage Storage, key string, file *multipart.FileHeader, contentType string) error {
+	content, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return storage.Put(ctx, key, content, file.Size, contentType)
+}
+
+// signingTime rounds links down to half their lifetime, so the same file gets
+// the same link for a while and clients can cache it. Links stay valid for at
+// least ttl/2.
+func signingTime(now time.Time, ttl time.Duration) time.Time {
+	return now.UTC().Truncate(ttl / 2)
+}
+
+// cleanStorageKey keeps keys relative and inside the storage
+func cleanStorageKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key {
+		return "", ErrInvalidStorageKey
+	}
+
+	return cleaned, nil
+}
+
+func NewLocalStorage(dir string, baseURL string, secret string, ttl time.Duration) *LocalStorage {
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  []byte(secret),
+		ttl:     ttl,
+	}
+}
+
+// Put writes to a temporary file first, readers never see a partial file
+func (s *LocalStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filePath)
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	filePath, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) URL(key string) string {
+	return s.SignedURL(key, signingTime(time.Now(), s.ttl))
+}
+
+// SignedURL returns the link to key signed at signedAt
+func (s *LocalStorage) SignedURL(key string, signedAt time.Time) string {
+	expires := strconv.FormatInt(signedAt.Add(s.ttl).Unix(), 10)
+
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {s.sign(key, expires)},
+	}
+
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+// Open checks the signature of a link and returns the path of its file
+func (s *LocalStorage) Open(key string, expires string, signature string) (string, time.Time, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return "", time.Time{}, ErrInvalidSignedURL
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return "", time.Time{}, ErrInvalidSignedURL
+	}
+
+	filePath, err := s.path(key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return filePath, time.Unix(expiresAt, 0), nil
+}
+
+func (s *LocalStorage) sign(key string, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	key, err := cleanStorageKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
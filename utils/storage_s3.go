@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lab-RPL-ITS/twitter-clone-api/config"
+)
+
+const (
+	s3Algorithm       = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+	s3TimeFormat      = "20060102T150405Z"
+)
+
+// S3Storage keeps files in a bucket of any S3 compatible service, requests
+// are signed with AWS Signature Version 4.
+type S3Storage struct {
+	config     config.S3Config
+	ttl        time.Duration
+	httpClient *http.Client
+}
+
+func NewS3Storage(s3Config config.S3Config, ttl time.Duration) *S3Storage {
+	return &S3Storage{
+		config:     s3Config,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	return s.do(req, http.StatusOK)
+}
+
+// Delete relies on S3 answering 204 whether or not the object existed
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.SignedURL(key, signingTime(time.Now(), s.ttl))
+}
+
+// SignedURL presigns a GET of key at signedAt, valid for the storage ttl
+func (s *S3Storage) SignedURL(key string, signedAt time.Time) string {
+	objectURL := s.objectURL(key)
+	signedAt = signedAt.UTC()
+
+	query := url.Values{
+		"X-Amz-Algorithm":     {s3Algorithm},
+		"X-Amz-Credential":    {s.config.AccessKeyID + "/" + s.scope(signedAt)},
+		"X-Amz-Date":          {signedAt.Format(s3TimeFormat)},
+		"X-Amz-Expires":       {strconv.Itoa(int(s.ttl.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		s3EscapePath(objectURL.Path),
+		s3CanonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(signedAt, canonicalRequest))
+	objectURL.RawQuery = s3CanonicalQuery(query)
+
+	return objectURL.String()
+}
+
+func (s *S3Storage) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if _, err := cleanStorageKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		method,
+		s3EscapePath(req.URL.Path),
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + s3UnsignedPayload,
+		"x-amz-date:" + now.Format(s3TimeFormat) + "\n",
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.config.AccessKeyID, s.scope(now), signedHeaders, s.signature(now, canonicalRequest)))
+
+	return req, nil
+}
+
+func (s *S3Storage) do(req *http.Request, statuses ...int) error {
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	for _, status := range statuses {
+		if res.StatusCode == status {
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s: unexpected status %d: %s", req.Method, req.URL.Path, res.StatusCode, body)
+}
+
+// objectURL addresses the bucket by path (endpoint/bucket/key) or, for AWS,
+// by subdomain (bucket.endpoint/key)
+func (s *S3Storage) objectURL(key string) *url.URL {
+	endpoint, _ := url.Parse(s.config.Endpoint)
+
+	objectURL := *endpoint
+	if s.config.PathStyle {
+		objectURL.Path = strings.TrimRight(endpoint.Path, "/") + "/" + s.config.Bucket + "/" + key
+	} else {
+		objectURL.Host = s.config.Bucket + "." + endpoint.Host
+		objectURL.Path = strings.TrimRight(endpoint.Path, "/") + "/" + key
+	}
+	objectURL.RawPath = s3EscapePath(objectURL.Path)
+
+	return &objectURL
+}
+
+func (s *S3Storage) scope(at time.Time) string {
+	return at.Format("20060102") + "/" + s.config.Region + "/s3/aws4_request"
+}
+
+func (s *S3Storage) signature(at time.Time, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		at.Format(s3TimeFormat),
+		s.scope(at),
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := []byte("AWS4" + s.config.SecretAccessKey)
+	for _, part := range []string{at.Format("20060102"), s.config.Region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+
+	return hex.EncodeToString(key)
+}
+
+// s3Escape percent-encodes everything but the unreserved characters of
+// RFC 3986, which is stricter than net/url
+func s3Escape(value string) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		if 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || b == '-' || b == '_' || b == '.' || b == '~' {
+			escaped.WriteByte(b)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range query[key] {
+			pairs = append(pairs, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
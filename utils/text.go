@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+type TextEntity struct {
+	Value string `json:"value"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// ExtractMentions returns every @username in text. Start and End are rune
+// offsets covering the whole mention including the "@".
+func ExtractMentions(text string) []TextEntity {
+	return extractEntities(text, '@')
+}
+
+// ExtractHashtags returns every #tag in text that contains at least one
+// letter, offsets are the same as ExtractMentions.
+func ExtractHashtags(text string) []TextEntity {
+	var hashtags []TextEntity
+	for _, hashtag := range extractEntities(text, '#') {
+		if strings.IndexFunc(hashtag.Value, unicode.IsLetter) >= 0 {
+			hashtags = append(hashtags, hashtag)
+		}
+	}
+
+	return hashtags
+}
+
+func extractEntities(text string, symbol rune) []TextEntity {
+	var entities []TextEntity
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != symbol {
+			continue
+		}
+
+		// "mail@example" or "@@name" are not entities
+		if i > 0 && (isEntityRune(runes[i-1]) || runes[i-1] == symbol) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isEntityRune(runes[end]) {
+			end++
+		}
+
+		if end == i+1 {
+			continue
+		}
+
+		entities = append(entities, TextEntity{
+			Value: string(runes[i+1 : end]),
+			Start: i,
+			End:   end,
+		})
+		i = end - 1
+	}
+
+	return entities
+}
+
+func isEntityRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
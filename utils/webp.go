@@ -0,0 +1,902 @@
+package utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"io"
+	"math"
+
+	"golang.org/x/image/draw"
+)
+
+// The encoder writes lossy WebP as a single VP8 key frame (RFC 6386). Every
+// macroblock is predicted as a whole, with the DC, TM, vertical or horizontal
+// mode that fits it best, and the token probabilities are tuned to the image
+// before it is written. The reconstruction follows the decoder step by step,
+// so predictions are made from the same pixels the decoder will have.
+
+var ErrWebPDimensions = errors.New("image too large for WebP")
+
+const (
+	vp8NumPlanes   = 4
+	vp8NumBands    = 8
+	vp8NumContexts = 3
+	vp8NumProbs    = 11
+
+	vp8PlaneY1WithY2 = 0
+	vp8PlaneY2       = 1
+	vp8PlaneUV       = 2
+
+	vp8MaxDimension = 1<<14 - 1
+	vp8MaxLevel     = 2048
+)
+
+const (
+	vp8PredDC = iota
+	vp8PredTM
+	vp8PredVE
+	vp8PredHE
+	vp8NumPreds
+)
+
+var (
+	vp8CoeffBands = [17]int{0, 1, 2, 3, 6, 4, 5, 6, 6, 6, 6, 6, 6, 6, 6, 7, 0}
+	vp8Zigzag     = [16]int{0, 1, 4, 8, 5, 2, 3, 6, 9, 12, 13, 10, 7, 11, 14, 15}
+	vp8CatProbs   = [4][]uint8{
+		{173, 148, 140},
+		{176, 155, 140, 135},
+		{180, 157, 141, 134, 130},
+		{254, 254, 243, 230, 196, 177, 153, 140, 133, 130, 129},
+	}
+)
+
+type (
+	vp8TokenProbs [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]uint8
+	vp8TokenStats [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs][2]uint32
+
+	// vp8Macroblock keeps the modes and quantized coefficients of one
+	// macroblock until the frame is written, coefficients in raster order
+	vp8Macroblock struct {
+		yMode  int
+		uvMode int
+		skip   bool
+		y2     [16]int16
+		y      [16][16]int16
+		uv     [8][16]int16
+	}
+
+	// vp8Plane is a padded plane of 8 bit samples
+	vp8Plane struct {
+		pix    []uint8
+		stride int
+	}
+
+	vp8Encoder struct {
+		width, height int
+		mbw, mbh      int
+
+		src [3]vp8Plane
+		rec [3]vp8Plane
+
+		qIndex int
+		y1     [2]int32
+		y2     [2]int32
+		uv     [2]int32
+
+		mbs []vp8Macroblock
+	}
+)
+
+// encodeWebP writes the picture as a lossy WebP, quality runs from 0 to 100
+// like for JPEG. Transparency is not kept.
+func encodeWebP(w io.Writer, m image.Image, quality int) error {
+	bounds := m.Bounds()
+	if bounds.Dx() > vp8MaxDimension || bounds.Dy() > vp8MaxDimension {
+		return ErrWebPDimensions
+	}
+
+	rgba, ok := m.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(bounds)
+		draw.Draw(rgba, bounds, m, bounds.Min, draw.Src)
+	}
+
+	e := newVP8Encoder(rgba, vp8QuantizerIndex(quality))
+	for mby := 0; mby < e.mbh; mby++ {
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			e.encodeMacroblock(mbx, mby)
+		}
+	}
+
+	frame := e.frame()
+	pad := len(frame) & 1
+
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(12+len(frame)+pad))
+	copy(header[8:], "WEBPVP8 ")
+	binary.LittleEndian.PutUint32(header[16:], uint32(len(frame)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(frame); err != nil {
+		return err
+	}
+	if pad == 1 {
+		if _, err := w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// vp8QuantizerIndex maps a quality to a quantizer index the way libwebp does,
+// 85 lands at 14.
+func vp8QuantizerIndex(quality int) int {
+	c := math.Max(0, math.Min(1, float64(quality)/100))
+	linear := 2*c - 1
+	if c < 0.75 {
+		linear = c * 2 / 3
+	}
+
+	q := int(127*(1-math.Cbrt(linear)) + 0.5)
+	return max(0, min(127, q))
+}
+
+func newVP8Encoder(m *image.RGBA, qIndex int) *vp8Encoder {
+	bounds := m.Bounds()
+	e := &vp8Encoder{
+		width:  bounds.Dx(),
+		height: bounds.Dy(),
+		mbw:    (bounds.Dx() + 15) / 16,
+		mbh:    (bounds.Dy() + 15) / 16,
+		qIndex: qIndex,
+	}
+
+	e.y1 = [2]int32{vp8DCQuant[qIndex], vp8ACQuant[qIndex]}
+	e.y2 = [2]int32{vp8DCQuant[qIndex] * 2, max(vp8ACQuant[qIndex]*155/100, 8)}
+	e.uv = [2]int32{vp8DCQuant[min(qIndex, 117)], vp8ACQuant[qIndex]}
+
+	width, height := 16*e.mbw, 16*e.mbh
+	for i := range e.src {
+		w, h := width, height
+		if i > 0 {
+			w, h = width/2, height/2
+		}
+		e.src[i] = vp8Plane{pix: make([]uint8, w*h), stride: w}
+		e.rec[i] = vp8Plane{pix: make([]uint8, w*h), stride: w}
+	}
+
+	// the padding repeats the last row and column of the picture
+	at := func(x, y int) (int, int, int) {
+		x = bounds.Min.X + min(x, e.width-1)
+		y = bounds.Min.Y + min(y, e.height-1)
+		i := m.PixOffset(x, y)
+		return int(m.Pix[i]), int(m.Pix[i+1]), int(m.Pix[i+2])
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b := at(x, y)
+			e.src[0].pix[y*width+x] = uint8((16839*r + 33059*g + 6420*b + 1<<15 + 16<<16) >> 16)
+		}
+	}
+
+	for y := 0; y < height/2; y++ {
+		for x := 0; x < width/2; x++ {
+			var r, g, b int
+			for _, d := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+				pr, pg, pb := at(2*x+d[0], 2*y+d[1])
+				r, g, b = r+pr, g+pg, b+pb
+			}
+
+			e.src[1].pix[y*width/2+x] = vp8ClipUV(-9719*r - 19081*g + 28800*b)
+			e.src[2].pix[y*width/2+x] = vp8ClipUV(28800*r - 24116*g - 4684*b)
+		}
+	}
+
+	e.mbs = make([]vp8Macroblock, e.mbw*e.mbh)
+	return e
+}
+
+// vp8ClipUV scales a chroma value summed over four pixels
+func vp8ClipUV(uv int) uint8 {
+	uv = (uv + 1<<17 + 128<<18) >> 18
+	return uint8(max(0, min(255, uv)))
+}
+
+// encodeMacroblock picks the prediction modes, quantizes the residuals and
+// reconstructs the macroblock like the decoder will.
+func (e *vp8Encoder) encodeMacroblock(mbx, mby int) {
+	mb := &e.mbs[mby*e.mbw+mbx]
+
+	var pred [256]uint8
+	mb.yMode = e.bestMode(mbx, mby, 16, []int{0})
+	e.predict(0, mbx, mby, 16, mb.yMode, pred[:])
+
+	src, rec := &e.src[0], &e.rec[0]
+	x0, y0 := 16*mbx, 16*mby
+
+	var coeffs [16][16]int16
+	var dc [16]int16
+	for n := 0; n < 16; n++ {
+		bx, by := 4*(n%4), 4*(n/4)
+		coeffs[n] = vp8ForwardDCT(src.pix[(y0+by)*src.stride+x0+bx:], src.stride, pred[by*16+bx:], 16)
+		dc[n] = coeffs[n][0]
+	}
+
+	y2 := vp8ForwardWHT(dc)
+	for i := range y2 {
+		mb.y2[i] = vp8Quantize(y2[i], e.y2[min(i, 1)], i == 0)
+	}
+
+	var y2deq [16]int16
+	for i := range y2deq {
+		y2deq[i] = int16(int32(mb.y2[i]) * e.y2[min(i, 1)])
+	}
+	dc = vp8InverseWHT(y2deq)
+
+	empty := true
+	for _, level := range mb.y2 {
+		empty = empty && level == 0
+	}
+
+	for n := 0; n < 16; n++ {
+		var deq [16]int16
+		deq[0] = dc[n]
+		nonZero := false
+		for i := 1; i < 16; i++ {
+			mb.y[n][i] = vp8Quantize(coeffs[n][i], e.y1[1], false)
+			deq[i] = int16(int32(mb.y[n][i]) * e.y1[1])
+			nonZero = nonZero || mb.y[n][i] != 0
+		}
+		empty = empty && !nonZero
+
+		bx, by := 4*(n%4), 4*(n/4)
+		out := rec.pix[(y0+by)*rec.stride+x0+bx:]
+		for j := 0; j < 4; j++ {
+			copy(out[j*rec.stride:j*rec.stride+4], pred[(by+j)*16+bx:(by+j)*16+bx+4])
+		}
+
+		if nonZero {
+			vp8InverseDCT(&deq, out, rec.stride)
+		} else if deq[0] != 0 {
+			vp8InverseDCTDCOnly(deq[0], out, rec.stride)
+		}
+	}
+
+	mb.uvMode = e.bestMode(mbx, mby, 8, []int{1, 2})
+	for p := 1; p <= 2; p++ {
+		e.predict(p, mbx, mby, 8, mb.uvMode, pred[:64])
+
+		src, rec := &e.src[p], &e.rec[p]
+		x0, y0 := 8*mbx, 8*mby
+
+		var deq [4][16]int16
+		nonZero := false
+		for n := 0; n < 4; n++ {
+			bx, by := 4*(n%2), 4*(n/2)
+			block := vp8ForwardDCT(src.pix[(y0+by)*src.stride+x0+bx:], src.stride, pred[by*8+bx:], 8)
+
+			levels := &mb.uv[4*(p-1)+n]
+			for i := range block {
+				levels[i] = vp8Quantize(block[i], e.uv[min(i, 1)], i == 0)
+				deq[n][i] = int16(int32(levels[i]) * e.uv[min(i, 1)])
+				nonZero = nonZero || levels[i] != 0
+			}
+		}
+		empty = empty && !nonZero
+
+		for n := 0; n < 4; n++ {
+			bx, by := 4*(n%2), 4*(n/2)
+			out := rec.pix[(y0+by)*rec.stride+x0+bx:]
+			for j := 0; j < 4; j++ {
+				copy(out[j*rec.stride:j*rec.stride+4], pred[(by+j)*8+bx:(by+j)*8+bx+4])
+			}
+
+			if nonZero {
+				vp8InverseDCT(&deq[n], out, rec.stride)
+			}
+		}
+	}
+
+	mb.skip = empty
+}
+
+// bestMode is the prediction mode closest to the source of the planes
+func (e *vp8Encoder) bestMode(mbx, mby, size int, planes []int) int {
+	best, bestErr := vp8PredDC, -1
+	pred := make([]uint8, size*size)
+
+	for mode := 0; mode < vp8NumPreds; mode++ {
+		sse := 0
+		for _, p := range planes {
+			e.predict(p, mbx, mby, size, mode, pred)
+
+			src := &e.src[p]
+			for y := 0; y < size; y++ {
+				row := src.pix[(size*mby+y)*src.stride+size*mbx:]
+				for x := 0; x < size; x++ {
+					d := int(row[x]) - int(pred[y*size+x])
+					sse += d * d
+				}
+			}
+		}
+
+		if bestErr < 0 || sse < bestErr {
+			best, bestErr = mode, sse
+		}
+	}
+
+	return best
+}
+
+// predict fills pred from the reconstructed edges of the macroblock. Outside
+// the picture the row above reads 127 and the column left 129, DC averages
+// only the edges that exist.
+func (e *vp8Encoder) predict(p, mbx, mby, size, mode int, pred []uint8) {
+	rec := &e.rec[p]
+	x0, y0 := size*mbx, size*mby
+
+	top := make([]int, size)
+	left := make([]int, size)
+	corner := 127
+	for i := 0; i < size; i++ {
+		top[i], left[i] = 127, 129
+		if mby > 0 {
+			top[i] = int(rec.pix[(y0-1)*rec.stride+x0+i])
+		}
+		if mbx > 0 {
+			left[i] = int(rec.pix[(y0+i)*rec.stride+x0-1])
+		}
+	}
+	if mby > 0 {
+		corner = 129
+		if mbx > 0 {
+			corner = int(rec.pix[(y0-1)*rec.stride+x0-1])
+		}
+	}
+
+	shift := 3
+	if size == 16 {
+		shift = 4
+	}
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			var v int
+			switch mode {
+			case vp8PredTM:
+				v = max(0, min(255, left[y]+top[x]-corner))
+			case vp8PredVE:
+				v = top[x]
+			case vp8PredHE:
+				v = left[y]
+			default:
+				v = vp8PredictDC(top, left, mbx, mby, shift)
+			}
+			pred[y*size+x] = uint8(v)
+		}
+	}
+}
+
+func vp8PredictDC(top, left []int, mbx, mby, shift int) int {
+	sum := 0
+	switch {
+	case mbx == 0 && mby == 0:
+		return 128
+	case mby == 0:
+		for _, v := range left {
+			sum += v
+		}
+		return (sum + 1<<(shift-1)) >> shift
+	case mbx == 0:
+		for _, v := range top {
+			sum += v
+		}
+		return (sum + 1<<(shift-1)) >> shift
+	}
+
+	for i := range top {
+		sum += top[i] + left[i]
+	}
+	return (sum + 1<<shift) >> (shift + 1)
+}
+
+// vp8Quantize rounds DC coefficients to the nearest step and AC coefficients
+// slightly towards zero, which saves more bits than it costs in quality
+func vp8Quantize(coeff int16, q int32, dc bool) int16 {
+	c := int32(coeff)
+	sign := int32(1)
+	if c < 0 {
+		sign, c = -1, -c
+	}
+
+	bias := q * 3 / 8
+	if dc {
+		bias = q / 2
+	}
+
+	level := min((c+bias)/q, vp8MaxLevel, math.MaxInt16/q)
+	return int16(sign * level)
+}
+
+// vp8ForwardDCT transforms the difference of a 4x4 block to its prediction,
+// the inverse of the decoder's transform as done by libwebp
+func vp8ForwardDCT(src []uint8, srcStride int, pred []uint8, predStride int) [16]int16 {
+	var tmp [16]int32
+	for i := 0; i < 4; i++ {
+		s, p := src[i*srcStride:], pred[i*predStride:]
+		d0 := int32(s[0]) - int32(p[0])
+		d1 := int32(s[1]) - int32(p[1])
+		d2 := int32(s[2]) - int32(p[2])
+		d3 := int32(s[3]) - int32(p[3])
+		a0, a1, a2, a3 := d0+d3, d1+d2, d1-d2, d0-d3
+		tmp[i*4+0] = (a0 + a1) * 8
+		tmp[i*4+1] = (a2*2217 + a3*5352 + 1812) >> 9
+		tmp[i*4+2] = (a0 - a1) * 8
+		tmp[i*4+3] = (a3*2217 - a2*5352 + 937) >> 9
+	}
+
+	var out [16]int16
+	for i := 0; i < 4; i++ {
+		a0 := tmp[i] + tmp[12+i]
+		a1 := tmp[4+i] + tmp[8+i]
+		a2 := tmp[4+i] - tmp[8+i]
+		a3 := tmp[i] - tmp[12+i]
+		out[i] = int16((a0 + a1 + 7) >> 4)
+		out[4+i] = int16((a2*2217+a3*5352+12000)>>16 + vp8Btoi(a3 != 0))
+		out[8+i] = int16((a0 - a1 + 7) >> 4)
+		out[12+i] = int16((a3*2217 - a2*5352 + 51000) >> 16)
+	}
+
+	return out
+}
+
+// vp8ForwardWHT transforms the DC coefficients of the 16 luma blocks
+func vp8ForwardWHT(dc [16]int16) [16]int16 {
+	var tmp [16]int32
+	for i := 0; i < 4; i++ {
+		in := dc[i*4:]
+		a0 := int32(in[0]) + int32(in[2])
+		a1 := int32(in[1]) + int32(in[3])
+		a2 := int32(in[1]) - int32(in[3])
+		a3 := int32(in[0]) - int32(in[2])
+		tmp[i*4+0] = a0 + a1
+		tmp[i*4+1] = a3 + a2
+		tmp[i*4+2] = a3 - a2
+		tmp[i*4+3] = a0 - a1
+	}
+
+	var out [16]int16
+	for i := 0; i < 4; i++ {
+		a0 := tmp[i] + tmp[8+i]
+		a1 := tmp[4+i] + tmp[12+i]
+		a2 := tmp[4+i] - tmp[12+i]
+		a3 := tmp[i] - tmp[8+i]
+		out[i] = int16((a0 + a1) >> 1)
+		out[4+i] = int16((a3 + a2) >> 1)
+		out[8+i] = int16((a3 - a2) >> 1)
+		out[12+i] = int16((a0 - a1) >> 1)
+	}
+
+	return out
+}
+
+// vp8InverseWHT recovers the DC coefficients of the 16 luma blocks
+func vp8InverseWHT(in [16]int16) [16]int16 {
+	var m [16]int32
+	for i := 0; i < 4; i++ {
+		a0 := int32(in[i]) + int32(in[12+i])
+		a1 := int32(in[4+i]) + int32(in[8+i])
+		a2 := int32(in[4+i]) - int32(in[8+i])
+		a3 := int32(in[i]) - int32(in[12+i])
+		m[i] = a0 + a1
+		m[8+i] = a0 - a1
+		m[4+i] = a3 + a2
+		m[12+i] = a3 - a2
+	}
+
+	var out [16]int16
+	for i := 0; i < 4; i++ {
+		dc := m[i*4] + 3
+		a0 := dc + m[i*4+3]
+		a1 := m[i*4+1] + m[i*4+2]
+		a2 := m[i*4+1] - m[i*4+2]
+		a3 := dc - m[i*4+3]
+		out[i*4+0] = int16((a0 + a1) >> 3)
+		out[i*4+1] = int16((a3 + a2) >> 3)
+		out[i*4+2] = int16((a0 - a1) >> 3)
+		out[i*4+3] = int16((a3 - a2) >> 3)
+	}
+
+	return out
+}
+
+// vp8InverseDCT adds the transformed residual to the predicted block in dst
+func vp8InverseDCT(coeff *[16]int16, dst []uint8, stride int) {
+	const (
+		c1 = 85627
+		c2 = 35468
+	)
+
+	var m [4][4]int32
+	for i := 0; i < 4; i++ {
+		a := int32(coeff[i]) + int32(coeff[8+i])
+		b := int32(coeff[i]) - int32(coeff[8+i])
+		c := (int32(coeff[4+i])*c2)>>16 - (int32(coeff[12+i])*c1)>>16
+		d := (int32(coeff[4+i])*c1)>>16 + (int32(coeff[12+i])*c2)>>16
+		m[i][0] = a + d
+		m[i][1] = b + c
+		m[i][2] = b - c
+		m[i][3] = a - d
+	}
+
+	for j := 0; j < 4; j++ {
+		dc := m[0][j] + 4
+		a := dc + m[2][j]
+		b := dc - m[2][j]
+		c := (m[1][j]*c2)>>16 - (m[3][j]*c1)>>16
+		d := (m[1][j]*c1)>>16 + (m[3][j]*c2)>>16
+		row := dst[j*stride:]
+		row[0] = vp8Clip8(int32(row[0]) + (a+d)>>3)
+		row[1] = vp8Clip8(int32(row[1]) + (b+c)>>3)
+		row[2] = vp8Clip8(int32(row[2]) + (b-c)>>3)
+		row[3] = vp8Clip8(int32(row[3]) + (a-d)>>3)
+	}
+}
+
+func vp8InverseDCTDCOnly(dc int16, dst []uint8, stride int) {
+	v := (int32(dc) + 4) >> 3
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			dst[j*stride+i] = vp8Clip8(int32(dst[j*stride+i]) + v)
+		}
+	}
+}
+
+func vp8Clip8(v int32) uint8 {
+	return uint8(max(0, min(255, v)))
+}
+
+func vp8Btoi(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// frame writes the key frame: the header, the first partition with the
+// modes and the token partition with the coefficients.
+func (e *vp8Encoder) frame() []byte {
+	var stats vp8TokenStats
+	e.writeTokens(&vp8TokenWriter{stats: &stats})
+	probs, updated := vp8TunedProbs(&stats)
+
+	skipped := 0
+	for _, mb := range e.mbs {
+		if mb.skip {
+			skipped++
+		}
+	}
+	skipProb := uint8(max(1, min(255, 255*(len(e.mbs)-skipped)/len(e.mbs))))
+
+	first := newVP8BoolEncoder()
+	first.putBit(false, 128) // color space
+	first.putBit(false, 128) // clamping type
+	first.putBit(false, 128) // segmentation
+	first.putBit(false, 128) // filter type
+	first.putLiteral(0, 6)   // filter level
+	first.putLiteral(0, 3)   // sharpness
+	first.putBit(false, 128) // filter deltas
+	first.putLiteral(0, 2)   // one token partition
+	first.putLiteral(uint32(e.qIndex), 7)
+	for i := 0; i < 5; i++ {
+		first.putBit(false, 128) // quantizer deltas
+	}
+	first.putBit(false, 128) // refresh entropy probs
+
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l := range probs[i][j][k] {
+					first.putBit(updated[i][j][k][l], vp8TokenUpdateProb[i][j][k][l])
+					if updated[i][j][k][l] {
+						first.putLiteral(uint32(probs[i][j][k][l]), 8)
+					}
+				}
+			}
+		}
+	}
+
+	first.putBit(true, 128)
+	first.putLiteral(uint32(skipProb), 8)
+
+	for _, mb := range e.mbs {
+		first.putBit(mb.skip, skipProb)
+		first.putBit(true, 145)
+
+		switch mb.yMode {
+		case vp8PredDC, vp8PredVE:
+			first.putBit(false, 156)
+			first.putBit(mb.yMode == vp8PredVE, 163)
+		default:
+			first.putBit(true, 156)
+			first.putBit(mb.yMode == vp8PredTM, 128)
+		}
+
+		first.putBit(mb.uvMode != vp8PredDC, 142)
+		if mb.uvMode != vp8PredDC {
+			first.putBit(mb.uvMode != vp8PredVE, 114)
+			if mb.uvMode != vp8PredVE {
+				first.putBit(mb.uvMode == vp8PredTM, 183)
+			}
+		}
+	}
+
+	tokens := newVP8BoolEncoder()
+	e.writeTokens(&vp8TokenWriter{enc: tokens, probs: &probs})
+
+	firstData, tokenData := first.flush(), tokens.flush()
+
+	header := make([]byte, 10)
+	tag := uint32(1<<4 | len(firstData)<<5)
+	header[0], header[1], header[2] = byte(tag), byte(tag>>8), byte(tag>>16)
+	header[3], header[4], header[5] = 0x9d, 0x01, 0x2a
+	binary.LittleEndian.PutUint16(header[6:], uint16(e.width))
+	binary.LittleEndian.PutUint16(header[8:], uint16(e.height))
+
+	return append(append(header, firstData...), tokenData...)
+}
+
+// writeTokens walks the coefficients in the order and with the contexts the
+// decoder reads them, a context counts the neighbours above and left with
+// non-zero coefficients.
+func (e *vp8Encoder) writeTokens(t *vp8TokenWriter) {
+	type nonZero struct {
+		y  [4]int
+		uv [4]int
+		y2 int
+	}
+
+	above := make([]nonZero, e.mbw)
+	for mby := 0; mby < e.mbh; mby++ {
+		var left nonZero
+		for mbx := 0; mbx < e.mbw; mbx++ {
+			mb := &e.mbs[mby*e.mbw+mbx]
+			up := &above[mbx]
+			if mb.skip {
+				left, *up = nonZero{}, nonZero{}
+				continue
+			}
+
+			nz := t.block(&mb.y2, vp8PlaneY2, left.y2+up.y2, 0)
+			left.y2, up.y2 = nz, nz
+
+			for y := 0; y < 4; y++ {
+				nz := left.y[y]
+				for x := 0; x < 4; x++ {
+					nz = t.block(&mb.y[y*4+x], vp8PlaneY1WithY2, nz+up.y[x], 1)
+					up.y[x] = nz
+				}
+				left.y[y] = nz
+			}
+
+			for c := 0; c < 4; c += 2 {
+				for y := 0; y < 2; y++ {
+					nz := left.uv[c+y]
+					for x := 0; x < 2; x++ {
+						nz = t.block(&mb.uv[2*c+y*2+x], vp8PlaneUV, nz+up.uv[c+x], 0)
+						up.uv[c+x] = nz
+					}
+					left.uv[c+y] = nz
+				}
+			}
+		}
+	}
+}
+
+// vp8TokenWriter codes tokens with probs, or only counts the branches taken
+// into stats when it has no encoder.
+type vp8TokenWriter struct {
+	enc   *vp8BoolEncoder
+	probs *vp8TokenProbs
+	stats *vp8TokenStats
+}
+
+func (t *vp8TokenWriter) put(bit bool, plane, band, ctx, i int) {
+	if t.enc == nil {
+		t.stats[plane][band][ctx][i][vp8Btoi(bit)]++
+		return
+	}
+	t.enc.putBit(bit, t.probs[plane][band][ctx][i])
+}
+
+func (t *vp8TokenWriter) fixed(bit bool, prob uint8) {
+	if t.enc != nil {
+		t.enc.putBit(bit, prob)
+	}
+}
+
+// block codes the coefficients of a block from position first on and
+// reports with 1 whether any of them is non-zero
+func (t *vp8TokenWriter) block(levels *[16]int16, plane, ctx, first int) int {
+	last := -1
+	for i := 15; i >= first; i-- {
+		if levels[vp8Zigzag[i]] != 0 {
+			last = i
+			break
+		}
+	}
+
+	band := vp8CoeffBands[first]
+	t.put(last >= 0, plane, band, ctx, 0)
+	if last < 0 {
+		return 0
+	}
+
+	for i := first; i <= last; i++ {
+		level := int(levels[vp8Zigzag[i]])
+		v := max(level, -level)
+		if v == 0 {
+			t.put(false, plane, band, ctx, 1)
+			band, ctx = vp8CoeffBands[i+1], 0
+			continue
+		}
+
+		t.put(true, plane, band, ctx, 1)
+		if v == 1 {
+			t.put(false, plane, band, ctx, 2)
+		} else {
+			t.put(true, plane, band, ctx, 2)
+			switch {
+			case v <= 4:
+				t.put(false, plane, band, ctx, 3)
+				t.put(v != 2, plane, band, ctx, 4)
+				if v != 2 {
+					t.put(v == 4, plane, band, ctx, 5)
+				}
+			case v <= 10:
+				t.put(true, plane, band, ctx, 3)
+				t.put(false, plane, band, ctx, 6)
+				t.put(v > 6, plane, band, ctx, 7)
+				if v <= 6 {
+					t.fixed(v == 6, 159)
+				} else {
+					t.fixed((v-7)&2 != 0, 165)
+					t.fixed((v-7)&1 != 0, 145)
+				}
+			default:
+				t.put(true, plane, band, ctx, 3)
+				t.put(true, plane, band, ctx, 6)
+
+				cat := 3
+				for c := 0; c < 3; c++ {
+					if v < 3+8<<(c+1) {
+						cat = c
+						break
+					}
+				}
+				t.put(cat >= 2, plane, band, ctx, 8)
+				t.put(cat&1 != 0, plane, band, ctx, 9+cat>>1)
+
+				extra := v - (3 + 8<<cat)
+				for k, prob := range vp8CatProbs[cat] {
+					t.fixed(extra>>(len(vp8CatProbs[cat])-1-k)&1 != 0, prob)
+				}
+			}
+		}
+
+		band, ctx = vp8CoeffBands[i+1], 2
+		if v == 1 {
+			ctx = 1
+		}
+
+		t.fixed(level < 0, 128)
+		if i == 15 {
+			break
+		}
+		t.put(i < last, plane, band, ctx, 0)
+	}
+
+	return 1
+}
+
+// vp8TunedProbs replaces a default token probability with the one seen in
+// the image wherever the bits saved pay for sending it.
+func vp8TunedProbs(stats *vp8TokenStats) (vp8TokenProbs, [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]bool) {
+	probs := vp8DefaultTokenProb
+	var updated [vp8NumPlanes][vp8NumBands][vp8NumContexts][vp8NumProbs]bool
+
+	cost := func(prob uint8, zeros, ones uint32) float64 {
+		p := float64(prob) / 256
+		return -float64(zeros)*math.Log2(p) - float64(ones)*math.Log2(1-p)
+	}
+
+	for i := range probs {
+		for j := range probs[i] {
+			for k := range probs[i][j] {
+				for l := range probs[i][j][k] {
+					zeros, ones := stats[i][j][k][l][0], stats[i][j][k][l][1]
+					if zeros+ones == 0 {
+						continue
+					}
+
+					tuned := uint8(max(1, min(255, (255*zeros+(zeros+ones)/2)/(zeros+ones))))
+					update := vp8TokenUpdateProb[i][j][k][l]
+					saved := cost(probs[i][j][k][l], zeros, ones) - cost(tuned, zeros, ones)
+					if saved > cost(update, 0, 1)-cost(update, 1, 0)+8 {
+						probs[i][j][k][l] = tuned
+						updated[i][j][k][l] = true
+					}
+				}
+			}
+		}
+	}
+
+	return probs, updated
+}
+
+// vp8BoolEncoder is the boolean entropy encoder of section 7.3
+type vp8BoolEncoder struct {
+	buf      []byte
+	rng      uint32
+	bottom   uint32
+	bitCount int
+}
+
+func newVP8BoolEncoder() *vp8BoolEncoder {
+	return &vp8BoolEncoder{rng: 255, bitCount: 24}
+}
+
+// putBit codes bit, prob is the chance out of 256 of it being false
+func (e *vp8BoolEncoder) putBit(bit bool, prob uint8) {
+	split := 1 + ((e.rng-1)*uint32(prob))>>8
+	if bit {
+		e.bottom += split
+		e.rng -= split
+	} else {
+		e.rng = split
+	}
+
+	for e.rng < 128 {
+		e.rng <<= 1
+		if e.bottom&(1<<31) != 0 {
+			e.carry()
+		}
+		e.bottom <<= 1
+
+		e.bitCount--
+		if e.bitCount == 0 {
+			e.buf = append(e.buf, byte(e.bottom>>24))
+			e.bottom &= 1<<24 - 1
+			e.bitCount = 8
+		}
+	}
+}
+
+func (e *vp8BoolEncoder) carry() {
+	for i := len(e.buf) - 1; i >= 0; i-- {
+		e.buf[i]++
+		if e.buf[i] != 0 {
+			return
+		}
+	}
+}
+
+func (e *vp8BoolEncoder) putLiteral(v uint32, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		e.putBit(v>>i&1 != 0, 128)
+	}
+}
+
+// flush pushes the pending bits out with padding, like libvpx does
+func (e *vp8BoolEncoder) flush() []byte {
+	for i := 0; i < 32; i++ {
+		e.putBit(false, 128)
+	}
+	return e.buf
+}